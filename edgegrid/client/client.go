@@ -1,10 +1,12 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
-
-	log "github.com/sirupsen/logrus"
+	"time"
 
 	"github.com/apiheat/go-edgegrid/v6/edgegrid"
 	"github.com/apiheat/go-edgegrid/v6/edgegrid/signer"
@@ -14,37 +16,72 @@ import (
 // A Client implements the base client request and response handling
 // used by all service clients.
 type Client struct {
-	Config  *edgegrid.Config
-	Rclient *resty.Client
+	Config      *edgegrid.Config
+	Logger      edgegrid.Logger
+	Rclient     *resty.Client
+	RateLimiter edgegrid.RateLimiter
+	ServiceName string
+}
+
+// WithServiceName sets name of the service client i.e. `netlistv2`
+// which is used to look up service specific configuration.
+func WithServiceName(name string) func(*Client) {
+	return func(c *Client) {
+		c.ServiceName = name
+	}
+}
+
+// WithRateLimiter sets default rate limiter used by the service client.
+// Limiter defined for the service in edgegrid.Config takes precedence.
+func WithRateLimiter(limiter edgegrid.RateLimiter) func(*Client) {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
 }
 
+// pendingHost is used as host of requests until it is replaced with
+// host of credentials retrieved from provider when request is signed
+const pendingHost = "edgegrid.invalid"
+
 // New will return a pointer to a new initialized service client.
-func New(cfg *edgegrid.Config, options ...func(*Client)) *Client {
+// Error is returned when config does not contain credentials or credentials provider.
+func New(cfg *edgegrid.Config, options ...func(*Client)) (*Client, error) {
+	if cfg == nil || (cfg.Credentials == nil && cfg.CredentialsProvider == nil) {
+		return nil, edgegrid.ErrorCredentials{
+			ErrorMessage: "Cannot create client without credentials",
+			ErrorType:    "ErrorCredentialsMissing",
+		}
+	}
+
 	svc := &Client{
 		Config: cfg,
+		Logger: cfg.Logger,
 	}
 
-	switch svc.Config.LogVerbosity {
-	case "debug":
-		log.SetLevel(log.DebugLevel)
-	case "warn":
-		log.SetLevel(log.WarnLevel)
-	case "info":
-		log.SetLevel(log.InfoLevel)
-	case "error":
-		log.SetLevel(log.ErrorLevel)
-	case "fatal":
-		log.SetLevel(log.FatalLevel)
-	case "panic":
-		log.SetLevel(log.PanicLevel)
+	if svc.Logger == nil {
+		svc.Logger = edgegrid.NewDefaultLogger(cfg.LogVerbosity)
 	}
 
-	if svc.Config.Credentials == nil {
-		log.Fatalln("Cannot create client without credentials!")
+	for _, option := range options {
+		option(svc)
+	}
+
+	if limiter, ok := svc.Config.RateLimiters[svc.ServiceName]; ok {
+		svc.RateLimiter = limiter
 	}
 
 	// Create instance of resty client
-	svc.Rclient = resty.New()
+	svc.Rclient = resty.New().SetLogger(svc.Logger)
+
+	// Instrument requests when OpenTelemetry providers are configured
+	tel, err := newTelemetry(cfg, svc.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if tel != nil {
+		svc.Rclient.SetTransport(tel.transport(svc.Rclient.GetClient().Transport))
+	}
 
 	//Sets headers and customize the user agent
 	svc.Rclient.SetHeaders(map[string]string{
@@ -54,15 +91,18 @@ func New(cfg *edgegrid.Config, options ...func(*Client)) *Client {
 
 	svc.Rclient.SetDebug(svc.Config.RequestDebug)
 
+	// Configure retries of transient failures
+	configureRetries(svc.Rclient, svc.Config.RetryPolicy)
+
 	if svc.Config.LocalTesting {
 		svc.Rclient.SetHostURL(svc.Config.TestingURL)
 
-	} else {
+	} else if svc.Config.Credentials != nil {
 		svc.Rclient.SetHostURL(fmt.Sprintf("%s://%s", svc.Config.Scheme, svc.Config.Credentials.Host))
-	}
 
-	// Create inistance of auth signer
-	authSigner := signer.New(svc.Config.Credentials, svc.Config.Scheme, svc.Config.Credentials.Host)
+	} else {
+		svc.Rclient.SetHostURL(fmt.Sprintf("%s://%s", svc.Config.Scheme, pendingHost))
+	}
 
 	// Registering Request Middleware - which will run just before every request is prepared
 	svc.Rclient.OnBeforeRequest(func(c *resty.Client, r *resty.Request) error {
@@ -71,6 +111,48 @@ func New(cfg *edgegrid.Config, options ...func(*Client)) *Client {
 			r.SetQueryParam("accountSwitchKey", svc.Config.AccountSwitchKey)
 		}
 
+		// Wait for our turn so we stay within API quotas
+		if svc.RateLimiter != nil {
+			start := time.Now()
+			if err := svc.RateLimiter.Wait(r.Context()); err != nil {
+				return err
+			}
+
+			if tel != nil {
+				tel.recordRateLimitWait(r.Context(), time.Since(start))
+			}
+		}
+
+		// Let telemetry distinguish retries of the request
+		if tel != nil {
+			r.SetContext(withAttempt(r.Context(), r.Attempt))
+		}
+
+		return nil
+	})
+
+	// Registering Response Middleware - which lets rate limiter adapt to quotas reported by Akamai
+	svc.Rclient.OnAfterResponse(func(c *resty.Client, r *resty.Response) error {
+
+		if svc.RateLimiter != nil {
+			svc.RateLimiter.Update(r.RawResponse)
+		}
+
+		return nil
+	})
+
+	// Registering Response Middleware - which runs interceptors defined in config
+	svc.Rclient.OnAfterResponse(func(c *resty.Client, r *resty.Response) error {
+
+		for _, intercept := range svc.Config.ResponseInterceptors {
+			// Body was already consumed by resty so each interceptor gets a fresh copy
+			r.RawResponse.Body = ioutil.NopCloser(bytes.NewReader(r.Body()))
+
+			if err := intercept(r.RawResponse); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 
@@ -78,11 +160,53 @@ func New(cfg *edgegrid.Config, options ...func(*Client)) *Client {
 	// preparation of the request.
 	svc.Rclient.SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
 
-		req.Header.Set("Authorization", authSigner.SignRequest(req, []string{}))
+		creds, err := svc.credentials(req.Context())
+		if err != nil {
+			return err
+		}
+
+		// Requests go to host of current credentials
+		if !svc.Config.LocalTesting && creds != nil && creds.Host != "" {
+			req.URL.Host = creds.Host
+			req.Host = creds.Host
+		}
+
+		// Account key of credentials is used unless config defines account switch key
+		if svc.Config.AccountSwitchKey == "" && creds != nil && creds.AccountKey != "" {
+			query := req.URL.Query()
+			query.Set("accountSwitchKey", creds.AccountKey)
+			req.URL.RawQuery = query.Encode()
+		}
+
+		for _, intercept := range svc.Config.RequestInterceptors {
+			if err := intercept(req); err != nil {
+				return err
+			}
+		}
+
+		// Create instance of auth signer with current credentials
+		authSigner := signer.New(creds, svc.Config.Scheme, req.Host)
 
 		// Set authentication header with signed data based on request
+		auth, err := authSigner.SignRequest(req, nil)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", auth)
+
 		return nil
 	})
 
-	return svc
+	return svc, nil
+}
+
+// credentials returns credentials used to sign request, provider
+// defined in config takes precedence over static credentials
+func (c *Client) credentials(ctx context.Context) (*edgegrid.Credentials, error) {
+	if c.Config.CredentialsProvider != nil {
+		return c.Config.CredentialsProvider.Retrieve(ctx)
+	}
+
+	return c.Config.Credentials, nil
 }
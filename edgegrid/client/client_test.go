@@ -0,0 +1,492 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/signer"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupClient prepares client pointing at given test server
+func setupClient(t *testing.T, baseURL string, policy *edgegrid.RetryPolicy) *Client {
+	creds, err := edgegrid.NewCredentials().FromJSON(`{ "client_secret": "kljwekfjf", "host": "akab-k2112.31k23jl1k23.luna.akamaiapis.net", "access_token": "akab-l12h3iu123y923huk-4uc54n5xmwhqu4zh", "client_token": "akab-90821u3hkjbnmk-jkhg" }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := edgegrid.NewConfig().
+		WithCredentials(creds).
+		WithLocalTesting(true).
+		WithScheme("http").
+		WithTestingURL(baseURL).
+		WithRetryPolicy(policy)
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// recorder collects authorization headers of received requests
+type recorder struct {
+	sync.Mutex
+	auth []string
+}
+
+func (r *recorder) add(req *http.Request) int {
+	r.Lock()
+	defer r.Unlock()
+	r.auth = append(r.auth, req.Header.Get("Authorization"))
+	return len(r.auth)
+}
+
+func TestRetryResignsEachAttempt(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rec.add(r) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := edgegrid.NewRetryPolicy().WithMaxAttempts(3).WithBackoff(time.Millisecond, 5*time.Millisecond)
+	c := setupClient(t, server.URL, policy)
+
+	resp, err := c.Rclient.R().Get("/test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+	}
+
+	if assert.Len(t, rec.auth, 3, "Request should be attempted 3 times") {
+		assert.NotEqual(t, rec.auth[0], rec.auth[1], "Each attempt should be signed again")
+		assert.NotEqual(t, rec.auth[1], rec.auth[2], "Each attempt should be signed again")
+	}
+}
+
+func TestRetrySkipsNonRetryableMethod(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.add(r)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := edgegrid.NewRetryPolicy().WithBackoff(time.Millisecond, 5*time.Millisecond)
+	c := setupClient(t, server.URL, policy)
+
+	resp, err := c.Rclient.R().SetBody(`{}`).Post("/test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode())
+	}
+	assert.Len(t, rec.auth, 1, "POST should not be retried on 502")
+}
+
+func TestRetryTooManyRequestsForAnyMethod(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rec.add(r) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	policy := edgegrid.NewRetryPolicy().WithBackoff(time.Millisecond, 5*time.Millisecond)
+	c := setupClient(t, server.URL, policy)
+
+	resp, err := c.Rclient.R().SetBody(`{}`).Post("/test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusCreated, resp.StatusCode())
+	}
+	assert.Len(t, rec.auth, 2, "POST rejected with 429 should be retried")
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC)
+
+	h := http.Header{}
+	_, ok := retryAfter(h, now)
+	assert.False(t, ok, "Missing header should not be parsed")
+
+	h.Set("Retry-After", "7")
+	wait, ok := retryAfter(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	h.Set("Retry-After", now.Add(90*time.Second).Format(http.TimeFormat))
+	wait, ok = retryAfter(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, wait)
+
+	h.Set("Retry-After", "soon")
+	_, ok = retryAfter(h, now)
+	assert.False(t, ok, "Invalid header should not be parsed")
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC)
+
+	b := NewTokenBucket(2, 2)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	assert.Equal(t, time.Duration(0), b.reserve(), "First token should be available")
+	assert.Equal(t, time.Duration(0), b.reserve(), "Second token should be available")
+	assert.Equal(t, 500*time.Millisecond, b.reserve(), "Bucket should be empty")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), b.reserve(), "Token should be refilled")
+}
+
+func TestTokenBucketUpdate(t *testing.T) {
+	now := time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC)
+
+	b := NewTokenBucket(10, 10)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	// Quota exhausted on the server side
+	h := http.Header{}
+	h.Set("Akamai-RateLimit-Limit", "5")
+	h.Set("Akamai-RateLimit-Remaining", "0")
+	h.Set("Akamai-RateLimit-Next", now.Add(3*time.Second).Format(time.RFC3339Nano))
+	b.Update(&http.Response{StatusCode: http.StatusOK, Header: h})
+
+	assert.Equal(t, float64(5), b.burst, "Burst should follow server side limit")
+	assert.Equal(t, 3*time.Second, b.reserve(), "Bucket should be paused until quota is replenished")
+
+	// Rejected request pauses bucket for Retry-After
+	now = now.Add(3 * time.Second)
+	h = http.Header{}
+	h.Set("Retry-After", "10")
+	b.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: h})
+
+	assert.Equal(t, 10*time.Second, b.reserve(), "Bucket should be paused after 429")
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	assert.NoError(t, b.Wait(context.Background()), "First token should be available")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, b.Wait(ctx), "Wait should respect context")
+}
+
+func TestSigningErrorIsReturned(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.add(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := setupClient(t, server.URL, edgegrid.NewRetryPolicy())
+	c.Config.Credentials = nil
+
+	_, err := c.Rclient.R().Get("/test")
+
+	var signErr signer.ErrorSignature
+	if assert.True(t, errors.As(err, &signErr), "Signing error should be returned to caller") {
+		assert.Equal(t, "ErrorSignatureCredentials", signErr.ErrorType)
+	}
+	assert.Empty(t, rec.auth, "Request should not be sent")
+}
+
+func TestNewWithoutCredentials(t *testing.T) {
+	level := logrus.GetLevel()
+
+	_, err := New(edgegrid.NewConfig().WithLogVerbosity("debug"))
+
+	var credErr edgegrid.ErrorCredentials
+	if assert.True(t, errors.As(err, &credErr)) {
+		assert.Equal(t, "ErrorCredentialsMissing", credErr.ErrorType)
+	}
+	assert.Equal(t, level, logrus.GetLevel(), "Global logger should not be modified")
+}
+
+func TestCredentialsProviderRetrievedOnSign(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.add(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	var tokens = []string{"akab-first", "akab-second"}
+	var calls int
+	provider := edgegrid.CredentialsProviderFunc(func(ctx context.Context) (*edgegrid.Credentials, error) {
+		creds := &edgegrid.Credentials{
+			Host:         serverURL.Host,
+			ClientToken:  tokens[calls%len(tokens)],
+			ClientSecret: "kljwekfjf",
+			AccessToken:  "akab-access",
+		}
+		calls++
+		return creds, nil
+	})
+
+	c, err := New(edgegrid.NewConfig().WithScheme("http").WithCredentialsProvider(provider))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for range tokens {
+		_, err = c.Rclient.R().Get("/test")
+		assert.NoError(t, err)
+	}
+
+	if assert.Len(t, rec.auth, 2, "Requests should be sent to host of credentials") {
+		assert.Contains(t, rec.auth[0], "client_token=akab-first;")
+		assert.Contains(t, rec.auth[1], "client_token=akab-second;")
+	}
+
+	// Account key of credentials is sent as account switch key
+	var query url.Values
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+	})
+	accountKey := edgegrid.CredentialsProviderFunc(func(ctx context.Context) (*edgegrid.Credentials, error) {
+		return &edgegrid.Credentials{Host: serverURL.Host, ClientToken: "akab-client", ClientSecret: "kljwekfjf", AccessToken: "akab-access", AccountKey: "1-ABCD"}, nil
+	})
+	c, _ = New(edgegrid.NewConfig().WithScheme("http").WithCredentialsProvider(accountKey))
+
+	_, err = c.Rclient.R().SetQueryParam("search", "x").Get("/test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1-ABCD", query.Get("accountSwitchKey"))
+		assert.Equal(t, "x", query.Get("search"))
+	}
+
+	failing := edgegrid.CredentialsProviderFunc(func(ctx context.Context) (*edgegrid.Credentials, error) {
+		return nil, errors.New("vault unavailable")
+	})
+	c, _ = New(edgegrid.NewConfig().WithScheme("http").WithCredentialsProvider(failing))
+
+	_, err = c.Rclient.R().Get("/test")
+	assert.Contains(t, err.Error(), "vault unavailable")
+	assert.Len(t, rec.auth, 2, "Request should not be sent")
+}
+
+// collectPages iterates pager over test server returning ids of items
+func collectPages(t *testing.T, c *Client, path string) ([]string, error) {
+	fetch := func(ctx context.Context, token *PageToken) ([]interface{}, *PageToken, error) {
+		var page struct {
+			Items []string `json:"items"`
+		}
+
+		resp, err := GetPage(c.Rclient.R().SetContext(ctx).SetResult(&page), path, token)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.IsError() {
+			return nil, nil, NewError(resp)
+		}
+
+		items := make([]interface{}, len(page.Items))
+		for i := range page.Items {
+			items[i] = page.Items[i]
+		}
+
+		return items, NextPageToken(resp.Body(), token, len(items)), nil
+	}
+
+	var ids []string
+	pager := NewPager(context.Background(), fetch)
+	for pager.Next() {
+		ids = append(ids, pager.Value().(string))
+	}
+
+	return ids, pager.Err()
+}
+
+type queryBase struct {
+	Page int `url:"page,omitempty"`
+}
+
+type queryOptions struct {
+	queryBase
+	Type     string    `url:"listType,omitempty"`
+	Extended bool      `url:"extended"`
+	IDs      []int     `url:"id"`
+	Fields   []string  `url:"fields,comma,omitempty"`
+	Limit    *int      `url:"limit"`
+	Since    time.Time `url:"since,omitempty"`
+	Ignored  string
+	Skipped  string `url:"-"`
+}
+
+func TestQueryParams(t *testing.T) {
+	limit := 0
+	query, err := QueryParams(&queryOptions{
+		queryBase: queryBase{Page: 2},
+		IDs:       []int{1, 2},
+		Fields:    []string{"name", "type"},
+		Limit:     &limit,
+		Since:     time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+		Ignored:   "x",
+		Skipped:   "y",
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, url.Values{
+			"page":     {"2"},
+			"extended": {"false"},
+			"id":       {"1", "2"},
+			"fields":   {"name,type"},
+			"limit":    {"0"},
+			"since":    {"2021-03-01T10:00:00Z"},
+		}, query)
+	}
+
+	query, err = QueryParams(queryOptions{Type: "IP", Extended: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "extended=true&listType=IP", query.Encode())
+	}
+
+	_, err = QueryParams("not a struct")
+	assert.Error(t, err)
+
+	_, err = QueryParams(struct {
+		M map[string]string `url:"m"`
+	}{M: map[string]string{}})
+	assert.Error(t, err)
+}
+
+func TestPagerFollowsLinks(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"items":["a","b"],"links":[{"rel":"self","href":"/list"},{"rel":"next","href":"/list?page=2"}]}`,
+		"2": `{"items":["c"],"links":{"next":{"href":"/list?page=3"}}}`,
+		"3": `{"items":["d"],"links":{}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	ids, err := collectPages(t, setupClient(t, server.URL, nil), "/list")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids)
+}
+
+func TestPagerFollowsOffset(t *testing.T) {
+	all := []string{"a", "b", "c", "d", "e"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(all) {
+			end = len(all)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":     all[offset:end],
+			"totalSize": len(all),
+		})
+	}))
+	defer server.Close()
+
+	ids, err := collectPages(t, setupClient(t, server.URL, nil), "/list")
+	assert.NoError(t, err)
+	assert.Equal(t, all, ids)
+}
+
+func TestPagerStopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"Not Found","status":404}`))
+			return
+		}
+		w.Write([]byte(`{"items":["a"],"next":"/list?page=2"}`))
+	}))
+	defer server.Close()
+
+	ids, err := collectPages(t, setupClient(t, server.URL, nil), "/list")
+	assert.Equal(t, []string{"a"}, ids)
+	assert.True(t, errors.Is(err, edgegrid.ErrNotFound))
+}
+
+func TestInterceptorsRunInOrder(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server:"+r.Header.Get("X-Trace-Id"))
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := setupClient(t, server.URL, nil)
+	c.Config.
+		WithRequestInterceptor(
+			func(req *http.Request) error {
+				calls = append(calls, "request1")
+				req.Header.Set("X-Trace-Id", "trace-1")
+				return nil
+			},
+			func(req *http.Request) error {
+				calls = append(calls, "request2:"+req.Header.Get("X-Trace-Id"))
+				return nil
+			}).
+		WithResponseInterceptor(
+			func(resp *http.Response) error {
+				body, _ := ioutil.ReadAll(resp.Body)
+				calls = append(calls, "response1:"+string(body))
+				return nil
+			},
+			func(resp *http.Response) error {
+				body, _ := ioutil.ReadAll(resp.Body)
+				calls = append(calls, "response2:"+resp.Request.Header.Get("X-Trace-Id")+":"+string(body))
+				return nil
+			})
+
+	_, err := c.Rclient.R().Get("/test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"request1",
+		"request2:trace-1",
+		"server:trace-1",
+		`response1:{"ok":true}`,
+		`response2:trace-1:{"ok":true}`,
+	}, calls)
+}
+
+func TestRequestInterceptorAbortsRequest(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.add(r)
+	}))
+	defer server.Close()
+
+	denied := errors.New("denied by policy")
+
+	c := setupClient(t, server.URL, nil)
+	c.Config.WithRequestInterceptor(func(req *http.Request) error {
+		return denied
+	})
+
+	_, err := c.Rclient.R().Delete("/test")
+	assert.True(t, errors.Is(err, denied))
+	assert.Empty(t, rec.auth, "Request should not be sent")
+}
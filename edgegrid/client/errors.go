@@ -0,0 +1,17 @@
+package client
+
+import (
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/go-resty/resty/v2"
+)
+
+// NewError wraps error response into edgegrid.Error. Service specific error
+// set on the request with SetError is kept as the wrapped error.
+func NewError(resp *resty.Response) error {
+	var serviceErr error
+	if e, ok := resp.Error().(error); ok {
+		serviceErr = e
+	}
+
+	return edgegrid.NewError(resp.RawResponse, resp.Body(), serviceErr)
+}
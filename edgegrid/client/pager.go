@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// PageToken describes how to retrieve next page of results. Href is used
+// when API returns link to next page, otherwise Offset is sent as query parameter.
+type PageToken struct {
+	Href   string
+	Offset int
+}
+
+// PageFetcher retrieves page of items described by token, nil token
+// requests first page. Returned token is nil when there are no more pages.
+type PageFetcher func(ctx context.Context, token *PageToken) ([]interface{}, *PageToken, error)
+
+// Pager iterates over items of paginated list, pages are fetched on demand.
+//
+//	pager := client.NewPager(ctx, fetch)
+//	for pager.Next() {
+//		item := pager.Value()
+//	}
+//	if err := pager.Err(); err != nil {
+//		// handle error
+//	}
+type Pager struct {
+	ctx   context.Context
+	fetch PageFetcher
+	token *PageToken
+	items []interface{}
+	index int
+	done  bool
+	err   error
+}
+
+// NewPager returns pager which uses fetch to retrieve pages.
+func NewPager(ctx context.Context, fetch PageFetcher) *Pager {
+	return &Pager{
+		ctx:   ctx,
+		fetch: fetch,
+		index: -1,
+	}
+}
+
+// Next advances to the next item, fetching next page when needed.
+// It returns false when there are no more items or an error occurred.
+func (p *Pager) Next() bool {
+	for {
+		if p.err != nil {
+			return false
+		}
+
+		if p.index+1 < len(p.items) {
+			p.index++
+			return true
+		}
+
+		if p.done {
+			return false
+		}
+
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		items, next, err := p.fetch(p.ctx, p.token)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		// Stop when API keeps pointing at the page we already have
+		if next == nil || (p.token != nil && *next == *p.token) {
+			p.done = true
+		}
+
+		p.token = next
+		p.items = items
+		p.index = -1
+	}
+}
+
+// Value returns current item, valid only after Next returned true.
+func (p *Pager) Value() interface{} {
+	if p.index < 0 || p.index >= len(p.items) {
+		return nil
+	}
+
+	return p.items[p.index]
+}
+
+// Err returns error which stopped the iteration.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// GetPage executes GET request for page described by token. First page is
+// requested from path, next ones from link href or path with `offset` query parameter.
+func GetPage(req *resty.Request, path string, token *PageToken) (*resty.Response, error) {
+	if token == nil {
+		return req.Get(path)
+	}
+
+	if token.Href != "" {
+		href, err := url.Parse(token.Href)
+		if err != nil {
+			return nil, err
+		}
+
+		// Link carries complete query of next page
+		req.QueryParam = href.Query()
+		href.RawQuery = ""
+
+		return req.Get(href.String())
+	}
+
+	return req.SetQueryParam("offset", strconv.Itoa(token.Offset)).Get(path)
+}
+
+// pageInfo represents pagination fields used across Akamai APIs
+type pageInfo struct {
+	Links      json.RawMessage `json:"links"`
+	Next       string          `json:"next"`
+	TotalSize  *int            `json:"totalSize"`
+	Total      *int            `json:"total"`
+	TotalCount *int            `json:"totalCount"`
+}
+
+// NextPageToken returns token of the page following the one in body.
+// Link with `next` relation ( either list of links or map keyed by relation )
+// and top level `next` field are followed first. Otherwise offset is advanced
+// by count of received items while it stays below total reported by API.
+func NextPageToken(body []byte, current *PageToken, count int) *PageToken {
+	var info pageInfo
+	if json.Unmarshal(body, &info) != nil {
+		return nil
+	}
+
+	if href := nextLink(info.Links); href != "" {
+		return &PageToken{Href: href}
+	}
+
+	if info.Next != "" {
+		return &PageToken{Href: info.Next}
+	}
+
+	total := info.TotalSize
+	if total == nil {
+		total = info.Total
+	}
+	if total == nil {
+		total = info.TotalCount
+	}
+
+	offset := count
+	if current != nil {
+		offset += current.Offset
+	}
+
+	if total == nil || count == 0 || offset >= *total {
+		return nil
+	}
+
+	return &PageToken{Offset: offset}
+}
+
+// nextLink finds href of `next` relation in links
+func nextLink(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var list []struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	}
+	if json.Unmarshal(raw, &list) == nil {
+		for _, link := range list {
+			if link.Rel == "next" {
+				return link.Href
+			}
+		}
+		return ""
+	}
+
+	var byRel map[string]json.RawMessage
+	if json.Unmarshal(raw, &byRel) != nil {
+		return ""
+	}
+
+	next, ok := byRel["next"]
+	if !ok {
+		return ""
+	}
+
+	var href string
+	if json.Unmarshal(next, &href) == nil {
+		return href
+	}
+
+	var link struct {
+		Href string `json:"href"`
+	}
+	if json.Unmarshal(next, &link) == nil {
+		return link.Href
+	}
+
+	return ""
+}
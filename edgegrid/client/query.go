@@ -0,0 +1,193 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryParams encodes fields of options struct tagged with `url` as query
+// parameters. Tag holds parameter name followed by options:
+//
+//	omitempty - zero value is not sent
+//	comma     - slice is sent as single comma separated value instead of repeated parameter
+//
+// Fields without tag ( or tagged `url:"-"` ) are skipped, nil pointers are
+// never sent and embedded structs are flattened. Supported are strings, bools,
+// numbers, time.Time ( RFC 3339 ), fmt.Stringer and slices and pointers of those.
+//
+//	type ListOptions struct {
+//		Type     string `url:"listType,omitempty"`
+//		Extended bool   `url:"extended"`
+//	}
+//
+//	query, err := client.QueryParams(opts)
+//	req.SetQueryParamsFromValues(query)
+func QueryParams(opts interface{}) (url.Values, error) {
+	values := url.Values{}
+
+	v := reflect.ValueOf(opts)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query params: expected struct, got %s", v.Kind())
+	}
+
+	if err := encodeStruct(values, v); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// encodeStruct adds tagged fields of struct to values
+func encodeStruct(values url.Values, v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		tag, tagged := field.Tag.Lookup("url")
+		if field.Anonymous && !tagged {
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !tagged || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+		if name == "" {
+			name = field.Name
+		}
+
+		if opts["omitempty"] && isEmptyValue(fv) {
+			continue
+		}
+
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Ptr {
+			continue
+		}
+
+		if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+			items := make([]string, 0, fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				s, err := formatValue(fv.Index(j))
+				if err != nil {
+					return fmt.Errorf("query params: field %s: %w", field.Name, err)
+				}
+				items = append(items, s)
+			}
+
+			if opts["comma"] {
+				values.Set(name, strings.Join(items, ","))
+				continue
+			}
+			for _, s := range items {
+				values.Add(name, s)
+			}
+			continue
+		}
+
+		s, err := formatValue(fv)
+		if err != nil {
+			return fmt.Errorf("query params: field %s: %w", field.Name, err)
+		}
+		values.Set(name, s)
+	}
+
+	return nil
+}
+
+// parseTag splits tag into parameter name and options
+func parseTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+
+	opts := map[string]bool{}
+	for _, o := range parts[1:] {
+		opts[strings.TrimSpace(o)] = true
+	}
+
+	return parts[0], opts
+}
+
+// formatValue returns string representation of single value
+func formatValue(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case time.Time:
+			return i.Format(time.RFC3339), nil
+		case fmt.Stringer:
+			return i.String(), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// isEmptyValue reports whether value is zero value of its type
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		if !v.CanInterface() {
+			return false
+		}
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.IsZero()
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Prefixes of rate limiting headers returned by Akamai APIs.
+var rateLimitHeaderPrefixes = []string{"Akamai-RateLimit-", "X-RateLimit-"}
+
+// TokenBucket implements edgegrid.RateLimiter using token bucket algorithm.
+// Bucket holds up to `burst` tokens and is refilled with `rate` tokens per second.
+//
+// Limiter adapts itself using rate limiting headers returned by Akamai
+// ( `Akamai-RateLimit-Limit`, `Akamai-RateLimit-Remaining`, `Akamai-RateLimit-Next`
+// and their `X-RateLimit-*` counterparts ) and pauses on `429 Too Many Requests`.
+type TokenBucket struct {
+	mu sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+
+	last        time.Time
+	pausedUntil time.Time
+
+	now func() time.Time
+}
+
+// NewTokenBucket returns a new TokenBucket allowing `rate` requests per second
+// with bursts of up to `burst` requests.
+//
+//	// Allow 100 requests per minute with bursts of 10 requests
+//	limiter := client.NewTokenBucket(100.0/60, 10)
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a token is available or context is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		if err := SleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Update adapts bucket to the quota reported by Akamai.
+func (b *TokenBucket) Update(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refill(now)
+
+	limit, remaining, next := rateLimitHeaders(resp.Header, now)
+
+	// Server side quota is smaller than our local bucket
+	if limit > 0 && float64(limit) < b.burst {
+		b.burst = float64(limit)
+		b.tokens = math.Min(b.tokens, b.burst)
+	}
+
+	if remaining >= 0 && float64(remaining) < b.tokens {
+		b.tokens = float64(remaining)
+	}
+
+	if remaining == 0 && next.After(b.pausedUntil) {
+		b.pausedUntil = next
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.tokens = 0
+
+		until := next
+		if wait, ok := retryAfter(resp.Header, now); ok {
+			until = now.Add(wait)
+		}
+		if until.IsZero() {
+			until = now.Add(b.interval())
+		}
+		if until.After(b.pausedUntil) {
+			b.pausedUntil = until
+		}
+	}
+}
+
+// reserve takes a token from the bucket or returns the time to wait for one.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refill(now)
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) * float64(b.interval()))
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+// interval returns time needed to generate a single token.
+func (b *TokenBucket) interval() time.Duration {
+	if b.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration(float64(time.Second) / b.rate)
+}
+
+// rateLimitHeaders reads limit, remaining requests and time when quota is
+// replenished. Missing values are reported as -1 and zero time.
+func rateLimitHeaders(h http.Header, now time.Time) (limit, remaining int, next time.Time) {
+	limit, remaining = -1, -1
+
+	for _, prefix := range rateLimitHeaderPrefixes {
+		if v, err := strconv.Atoi(h.Get(prefix + "Limit")); err == nil && limit < 0 {
+			limit = v
+		}
+		if v, err := strconv.Atoi(h.Get(prefix + "Remaining")); err == nil && remaining < 0 {
+			remaining = v
+		}
+		if v := h.Get(prefix + "Next"); v != "" && next.IsZero() {
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				next = t
+			}
+		}
+		if v, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil && next.IsZero() {
+			// Reset is either epoch timestamp or number of seconds
+			if v > 1000000000 {
+				next = time.Unix(v, 0)
+			} else {
+				next = now.Add(time.Duration(v) * time.Second)
+			}
+		}
+	}
+
+	return limit, remaining, next
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/go-resty/resty/v2"
+)
+
+// configureRetries applies retry policy onto underlying resty client.
+// Resty prepares every attempt from scratch, so the pre-request hook signs
+// each retry with a fresh nonce and timestamp.
+func configureRetries(rc *resty.Client, policy *edgegrid.RetryPolicy) {
+	if policy == nil || policy.MaxAttempts < 2 {
+		return
+	}
+
+	rc.SetRetryCount(policy.MaxAttempts - 1).
+		SetRetryWaitTime(policy.MinBackoff).
+		SetRetryMaxWaitTime(policy.MaxBackoff)
+
+	rc.AddRetryCondition(func(r *resty.Response, err error) bool {
+		// Request failed before being sent ( i.e. could not be signed )
+		if r == nil || r.Request == nil {
+			return false
+		}
+
+		return policy.Retryable(r.Request.Method, r.RawResponse, err)
+	})
+
+	if policy.RespectRetryAfter {
+		rc.SetRetryAfter(func(c *resty.Client, r *resty.Response) (time.Duration, error) {
+			if r == nil || r.RawResponse == nil {
+				return 0, nil
+			}
+
+			// (0, nil) lets resty fall back to exponential backoff with jitter
+			wait, _ := retryAfter(r.Header(), time.Now())
+			return wait, nil
+		})
+	}
+}
+
+// retryAfter parses `Retry-After` header which holds either
+// number of seconds or HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, false
+	}
+
+	return 0, false
+}
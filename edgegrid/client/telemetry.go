@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName identifies tracer and meter used by the library
+const instrumentationName = "github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+
+type operationKey struct{}
+type attemptKey struct{}
+
+// WithOperation returns context annotated with name of API operation
+// i.e. `ActivateNetworkList`. Name is used for telemetry of requests made with the context.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns name of API operation set with WithOperation.
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// withAttempt annotates context with number of request attempt
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// telemetry records OpenTelemetry spans and metrics of requests made by service client
+type telemetry struct {
+	service string
+	tracer  trace.Tracer
+
+	calls         metric.Int64Counter
+	errors        metric.Int64Counter
+	retries       metric.Int64Counter
+	duration      metric.Float64Histogram
+	rateLimitWait metric.Float64Histogram
+}
+
+// newTelemetry creates instruments using providers from config. It returns nil
+// when neither tracer nor meter provider is configured.
+func newTelemetry(cfg *edgegrid.Config, service string) (*telemetry, error) {
+	if cfg.TracerProvider == nil && cfg.MeterProvider == nil {
+		return nil, nil
+	}
+
+	var tp trace.TracerProvider = tracenoop.NewTracerProvider()
+	if cfg.TracerProvider != nil {
+		tp = cfg.TracerProvider
+	}
+
+	var mp metric.MeterProvider = metricnoop.NewMeterProvider()
+	if cfg.MeterProvider != nil {
+		mp = cfg.MeterProvider
+	}
+
+	t := &telemetry{
+		service: service,
+		tracer:  tp.Tracer(instrumentationName),
+	}
+
+	meter := mp.Meter(instrumentationName)
+
+	var err error
+	if t.calls, err = meter.Int64Counter("edgegrid.client.calls",
+		metric.WithDescription("Number of requests sent to Akamai APIs")); err != nil {
+		return nil, err
+	}
+	if t.errors, err = meter.Int64Counter("edgegrid.client.errors",
+		metric.WithDescription("Number of requests which failed or returned error status")); err != nil {
+		return nil, err
+	}
+	if t.retries, err = meter.Int64Counter("edgegrid.client.retries",
+		metric.WithDescription("Number of retried requests")); err != nil {
+		return nil, err
+	}
+	if t.duration, err = meter.Float64Histogram("edgegrid.client.duration",
+		metric.WithDescription("Duration of requests sent to Akamai APIs"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if t.rateLimitWait, err = meter.Float64Histogram("edgegrid.client.ratelimit.wait",
+		metric.WithDescription("Time requests waited for rate limiter"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// attributes returns attributes identifying service and operation of the request
+func (t *telemetry) attributes(ctx context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("edgegrid.service", t.service),
+		attribute.String("edgegrid.operation", OperationFromContext(ctx)),
+	}
+}
+
+// recordRateLimitWait records time request spent waiting for rate limiter
+func (t *telemetry) recordRateLimitWait(ctx context.Context, wait time.Duration) {
+	t.rateLimitWait.Record(ctx, wait.Seconds(), metric.WithAttributes(t.attributes(ctx)...))
+}
+
+// spanName returns name of the span i.e. `netlistv2.ActivateNetworkList`
+func (t *telemetry) spanName(req *http.Request) string {
+	if operation := OperationFromContext(req.Context()); operation != "" {
+		return t.service + "." + operation
+	}
+
+	return t.service + "." + req.Method
+}
+
+// redactedQueryParams are query parameters which values are not recorded in spans
+var redactedQueryParams = []string{"accountSwitchKey"}
+
+// redactURL returns URL without user info and with values of sensitive query parameters redacted
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+
+	query := redacted.Query()
+	for _, param := range redactedQueryParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, "REDACTED")
+		}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
+
+// transport wraps round tripper so that every request attempt gets a span and metrics
+func (t *telemetry) transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		attempt, _ := ctx.Value(attemptKey{}).(int)
+
+		ctx, span := t.tracer.Start(ctx, t.spanName(req),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.full", redactURL(req.URL)),
+				attribute.String("server.address", req.URL.Hostname()),
+			),
+			trace.WithAttributes(t.attributes(ctx)...),
+		)
+		defer span.End()
+
+		attrs := metric.WithAttributes(t.attributes(ctx)...)
+
+		if attempt > 1 {
+			span.SetAttributes(attribute.Int("http.request.resend_count", attempt-1))
+			t.retries.Add(ctx, 1, attrs)
+		}
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req.WithContext(ctx))
+
+		t.calls.Add(ctx, 1, attrs)
+		t.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			t.errors.Add(ctx, 1, attrs)
+
+			return resp, err
+		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		for _, header := range []string{"X-Akamai-Request-Id", "X-Request-Id", "X-Trace-Id"} {
+			if id := resp.Header.Get(header); id != "" {
+				span.SetAttributes(attribute.String("akamai.request_id", id))
+				break
+			}
+		}
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			t.errors.Add(ctx, 1, attrs)
+		}
+
+		return resp, nil
+	})
+}
+
+// roundTripperFunc adapts function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
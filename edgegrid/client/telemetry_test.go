@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupInstrumentedClient prepares client recording spans and metrics
+func setupInstrumentedClient(t *testing.T, baseURL string, policy *edgegrid.RetryPolicy) (*Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	creds, err := edgegrid.NewCredentials().FromJSON(`{ "client_secret": "kljwekfjf", "host": "akab-k2112.31k23jl1k23.luna.akamaiapis.net", "access_token": "akab-l12h3iu123y923huk-4uc54n5xmwhqu4zh", "client_token": "akab-90821u3hkjbnmk-jkhg" }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := edgegrid.NewConfig().
+		WithCredentials(creds).
+		WithLocalTesting(true).
+		WithScheme("http").
+		WithTestingURL(baseURL).
+		WithRetryPolicy(policy).
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))).
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	c, err := New(cfg, WithServiceName("test"), WithRateLimiter(NewTokenBucket(100, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, exporter, reader
+}
+
+// sumOf returns total of counter or number of histogram records with given name
+func sumOf(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			// Data points are generic types, read counter value or histogram count
+			points := reflect.ValueOf(m.Data).FieldByName("DataPoints")
+			for i := 0; i < points.Len(); i++ {
+				if v := points.Index(i).FieldByName("Value"); v.IsValid() {
+					total += v.Int()
+				} else {
+					total += int64(points.Index(i).FieldByName("Count").Uint())
+				}
+			}
+		}
+	}
+
+	return total
+}
+
+func TestTelemetrySpanPerAttempt(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Akamai-Request-Id", "req-"+strconv.Itoa(attempts))
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := edgegrid.NewRetryPolicy().WithMaxAttempts(2).WithBackoff(time.Millisecond, 5*time.Millisecond)
+	c, exporter, reader := setupInstrumentedClient(t, server.URL, policy)
+
+	ctx := WithOperation(context.Background(), "GetThing")
+	_, err := c.Rclient.R().SetContext(ctx).Get("/test")
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2, "Every attempt should have a span") {
+		assert.Equal(t, "test.GetThing", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Contains(t, spans[0].Attributes, attribute.String("akamai.request_id", "req-1"))
+		assert.Contains(t, spans[0].Attributes, attribute.String("edgegrid.operation", "GetThing"))
+		assert.Contains(t, spans[0].Attributes, attribute.Int("http.response.status_code", http.StatusServiceUnavailable))
+
+		assert.Equal(t, codes.Unset, spans[1].Status.Code)
+		assert.Contains(t, spans[1].Attributes, attribute.Int("http.request.resend_count", 1))
+	}
+
+	assert.Equal(t, int64(2), sumOf(t, reader, "edgegrid.client.calls"))
+	assert.Equal(t, int64(1), sumOf(t, reader, "edgegrid.client.errors"))
+	assert.Equal(t, int64(1), sumOf(t, reader, "edgegrid.client.retries"))
+	assert.Equal(t, int64(2), sumOf(t, reader, "edgegrid.client.duration"))
+	assert.Equal(t, int64(2), sumOf(t, reader, "edgegrid.client.ratelimit.wait"))
+}
+
+func TestTelemetryRedactsAccountSwitchKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, exporter, _ := setupInstrumentedClient(t, server.URL, nil)
+	c.Config.AccountSwitchKey = "1-ABCD:1-EFGH"
+
+	_, err := c.Rclient.R().SetQueryParam("search", "x").Get("/test")
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Contains(t, spans[0].Attributes, attribute.String("url.full", server.URL+"/test?accountSwitchKey=REDACTED&search=x"))
+	}
+}
+
+func TestTelemetryDisabledByDefault(t *testing.T) {
+	c := setupClient(t, "http://localhost", nil)
+	_, ok := c.Rclient.GetClient().Transport.(roundTripperFunc)
+	assert.False(t, ok, "Transport should not be wrapped without providers")
+}
@@ -1,5 +1,12 @@
 package edgegrid
 
+import (
+	"os"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
 // Config represents options that are passed during client initialization
 type Config struct {
 	// Defines account switch key used to manage sub-accounts with partner API keys
@@ -8,21 +15,48 @@ type Config struct {
 	// Credentials holds the current credentials configuration
 	Credentials *Credentials
 
+	// CredentialsProvider is asked for credentials every time request is signed.
+	// When set it takes precedence over Credentials
+	CredentialsProvider CredentialsProvider
+
 	// LocalTesting determines if the host we would be using is local - so we can run tests
 	LocalTesting bool
 
 	// Defines log level output i.e. debug/error/warning/info
 	LogVerbosity string
 
+	// Logger used by service clients. When nil a dedicated logrus logger
+	// with LogVerbosity level is created
+	Logger Logger
+
+	// MeterProvider enables OpenTelemetry metrics of requests when set
+	MeterProvider metric.MeterProvider
+
+	// RateLimiters overrides rate limiters used by service clients. Map is keyed
+	// by service name i.e. `fastpurgev3`, nil value disables rate limiting for the service
+	RateLimiters map[string]RateLimiter
+
 	// RequestDebug determines if we should print out debug info for http request/responses we make
 	RequestDebug bool
 
+	// RequestInterceptors are called in order of registration before every request is signed
+	RequestInterceptors []RequestInterceptor
+
+	// ResponseInterceptors are called in order of registration for every response received
+	ResponseInterceptors []ResponseInterceptor
+
+	// RetryPolicy defines how transient failures are retried. Retries are disabled when nil
+	RetryPolicy *RetryPolicy
+
 	// Scheme used ( http or https )
 	Scheme string
 
 	// TestingURL sets our desired url for mocked server
 	TestingURL string
 
+	// TracerProvider enables OpenTelemetry tracing of requests when set
+	TracerProvider trace.TracerProvider
+
 	// Used for adding the User Agent header for the requests we make towards APIs
 	UserAgent string
 }
@@ -35,10 +69,16 @@ type Config struct {
 //   cfg := edgegrid.NewConfig().WithAccountSwitchKey("MS-123BV")
 //
 func NewConfig() *Config {
+	// Log level can be set with AKAMAI_EDGERC_DEBUGLEVEL
+	logVerbosity := os.Getenv(string(EnvVarDebugLevelSection))
+	if logVerbosity == "" {
+		logVerbosity = "info"
+	}
+
 	// Return new config object with default values
 	return &Config{
 		RequestDebug: false,
-		LogVerbosity: "info",
+		LogVerbosity: logVerbosity,
 		Scheme:       "https",
 		UserAgent:    "apiheat/go-edgegrid/v6.1.0",
 	}
@@ -58,6 +98,13 @@ func (c *Config) WithLogVerbosity(logVerbosity string) *Config {
 	return c
 }
 
+// WithLogger sets a logger used by service clients and returns
+// a Config pointer.
+func (c *Config) WithLogger(logger Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
 // WithCredentials sets a config Credentials value returning a Config pointer
 // for chaining.
 func (c *Config) WithCredentials(creds *Credentials) *Config {
@@ -65,6 +112,13 @@ func (c *Config) WithCredentials(creds *Credentials) *Config {
 	return c
 }
 
+// WithCredentialsProvider sets a provider asked for credentials when requests
+// are signed returning a Config pointer for chaining.
+func (c *Config) WithCredentialsProvider(provider CredentialsProvider) *Config {
+	c.CredentialsProvider = provider
+	return c
+}
+
 // WithLocalTesting sets a config value to determine if local testing is being used and returns
 // a Config pointer.
 func (c *Config) WithLocalTesting(localTesting bool) *Config {
@@ -93,6 +147,52 @@ func (c *Config) WithTestingURL(testingURL string) *Config {
 	return c
 }
 
+// WithRateLimiter sets a config rate limiter used by given service client
+// i.e. `fastpurgev3` and returns a Config pointer. Passing nil limiter
+// disables rate limiting for the service.
+func (c *Config) WithRateLimiter(service string, limiter RateLimiter) *Config {
+	if c.RateLimiters == nil {
+		c.RateLimiters = map[string]RateLimiter{}
+	}
+	c.RateLimiters[service] = limiter
+	return c
+}
+
+// WithRetryPolicy sets a config retry policy used for transient failures and returns
+// a Config pointer.
+func (c *Config) WithRetryPolicy(policy *RetryPolicy) *Config {
+	c.RetryPolicy = policy
+	return c
+}
+
+// WithRequestInterceptor appends interceptors called before every request
+// is signed and returns a Config pointer. Interceptors run in order of registration.
+func (c *Config) WithRequestInterceptor(interceptors ...RequestInterceptor) *Config {
+	c.RequestInterceptors = append(c.RequestInterceptors, interceptors...)
+	return c
+}
+
+// WithResponseInterceptor appends interceptors called for every response
+// and returns a Config pointer. Interceptors run in order of registration.
+func (c *Config) WithResponseInterceptor(interceptors ...ResponseInterceptor) *Config {
+	c.ResponseInterceptors = append(c.ResponseInterceptors, interceptors...)
+	return c
+}
+
+// WithTracerProvider sets OpenTelemetry tracer provider used to create span
+// for every request and returns a Config pointer.
+func (c *Config) WithTracerProvider(tp trace.TracerProvider) *Config {
+	c.TracerProvider = tp
+	return c
+}
+
+// WithMeterProvider sets OpenTelemetry meter provider used to record metrics
+// of calls, errors, retries and rate limiter waits and returns a Config pointer.
+func (c *Config) WithMeterProvider(mp metric.MeterProvider) *Config {
+	c.MeterProvider = mp
+	return c
+}
+
 // WithRequestDebug toggles debug of http requests/repsonse output
 func (c *Config) WithRequestDebug(requestDebug bool) *Config {
 	c.RequestDebug = requestDebug
@@ -1,12 +1,15 @@
 package edgegrid
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/go-ini/ini"
-	log "github.com/sirupsen/logrus"
 	"github.com/thedevsaddam/gojsonq"
 )
 
@@ -19,6 +22,18 @@ type Credentials struct {
 	ClientSecret string `ini:"client_secret" json:"client_secret" valid:"required~ClientSecret name is blank/empty"`
 	AccessToken  string `ini:"access_token" json:"access_token" valid:"required~AccessToken name is blank/empty"`
 
+	// MaxBody defines maximum number of request body bytes used for
+	// content hash. Defaults to 131072 when not set.
+	MaxBody int `ini:"max_body" json:"max_body"`
+
+	// HeadersToSign lists request headers which are part of the signature.
+	// In .edgerc file headers are separated with comma.
+	HeadersToSign []string `ini:"headers_to_sign" json:"headers_to_sign" delim:","`
+
+	// AccountKey is account switch key of the credentials. It is used
+	// when Config does not define AccountSwitchKey.
+	AccountKey string `ini:"account_key" json:"account_key"`
+
 	//Netstorage based credentials
 	HostName string `ini:"hostname"`
 	Key      string `ini:"key"`
@@ -30,11 +45,25 @@ type Credentials struct {
 type ErrorCredentials struct {
 	ErrorMessage string `json:"error_message"`
 	ErrorType    string `json:"error_type"`
+
+	// Attempts lists sources which were tried and why they failed
+	Attempts []CredentialsAttempt `json:"attempts,omitempty"`
+}
+
+// CredentialsAttempt describes credentials source which failed.
+type CredentialsAttempt struct {
+	Source string
+	Err    error
 }
 
 // ErrorCredentials implements the error interface.
 func (e ErrorCredentials) Error() string {
-	return e.ErrorMessage
+	msg := e.ErrorMessage
+	for _, a := range e.Attempts {
+		msg += fmt.Sprintf("\n\t%s: %s", a.Source, a.Err)
+	}
+
+	return msg
 }
 
 //CredentialsBuilder provides method to build credentials using
@@ -50,40 +79,79 @@ type CredentialsBuilder struct {
 	// credentialsType defines what type of credentials we are dealing with
 	// Can be either `api` or `netstorage`
 	credentialsType string
-}
 
-//AutoLoad Tries to load credentials automatically from a environment variables or from section file.
-func (ea *CredentialsBuilder) AutoLoad(section string) *Credentials {
-	var creds *Credentials
-	var err error
-	var homeDir string
+	// logger used while retrieving credentials, discards messages by default
+	logger Logger
+}
 
-	creds = &Credentials{}
+// AutoLoad Tries to load credentials automatically from environment variables or from section of .edgerc file.
+//
+// Section defaults to AKAMAI_EDGERC_SECTION variable or `default`. Environment variables
+// of the section are used first ( see FromEnvSection ), then the section of file set by
+// AKAMAI_EDGERC_CONFIG variable or ~/.edgerc. Returned error lists every source tried.
+//
+//	creds, err := edgegrid.NewCredentials().AutoLoad("")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+func (ea *CredentialsBuilder) AutoLoad(section string) (*Credentials, error) {
+	if section == "" {
+		section = os.Getenv(string(EnvVarEdgercSection))
+	}
 	if section == "" {
 		section = "default"
 	}
 
-	creds, err = NewCredentials().FromEnv()
-	if err != nil {
-		homeDir, err = os.UserHomeDir()
-		if err != nil {
-			return nil
-		}
-		log.WithFields(log.Fields{
-			"homedir": homeDir,
-		}).Debugln("Edgerc file location (.edgerc) ")
+	ea.logger.Debugf("Section in credentials: %s", section)
+
+	env := NewEnvCredentialsProvider(section)
+	env.logger = ea.logger
+
+	file := NewEdgercCredentialsProvider("", section)
+	file.logger = ea.logger
+
+	creds, err := NewChainCredentialsProvider(env, file).Retrieve(context.Background())
+	if e, ok := err.(ErrorCredentials); ok {
+		e.ErrorMessage = fmt.Sprintf("Could not load credentials for section %q", section)
+		return nil, e
+	}
+
+	return creds, err
+}
 
-		log.WithFields(log.Fields{
-			"section": section,
-		}).Debugln("Section in credentials")
+// edgercPath returns path of .edgerc file set by AKAMAI_EDGERC_CONFIG or ~/.edgerc
+func edgercPath() (string, error) {
+	path := os.Getenv(string(EnvVarEdgercPath))
 
-		creds, err = NewCredentials().FromFile(homeDir + "/.edgerc").Section(section)
+	if path == "" || path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			return nil
+			return "", err
 		}
+
+		if path == "" {
+			return filepath.Join(homeDir, ".edgerc"), nil
+		}
+		return filepath.Join(homeDir, strings.TrimPrefix(path, "~")), nil
+	}
+
+	return path, nil
+}
+
+// envPrefix returns prefix of environment variables holding credentials of section
+func envPrefix(section string) string {
+	if section == "" || section == "default" {
+		return "AKAMAI_"
 	}
 
-	return creds
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, strings.ToUpper(section))
+
+	return "AKAMAI_" + name + "_"
 }
 
 // NewCredentials is used to create new object on which we can chain our methods
@@ -96,9 +164,21 @@ func (ea *CredentialsBuilder) AutoLoad(section string) *Credentials {
 func NewCredentials() *CredentialsBuilder {
 	return &CredentialsBuilder{
 		edgercSection: "default",
+		logger:        NewNopLogger(),
 	}
 }
 
+// WithLogger sets logger used for debug output while retrieving credentials.
+// Nil logger discards messages.
+func (ea *CredentialsBuilder) WithLogger(logger Logger) *CredentialsBuilder {
+	if logger == nil {
+		logger = NewNopLogger()
+	}
+	ea.logger = logger
+
+	return ea
+}
+
 // FromEnv Retrieves credentials from env variables which are prefixed with 'AKAMAI_'
 // In order to sucesfully build credentials file we need the following variables:
 //
@@ -107,6 +187,8 @@ func NewCredentials() *CredentialsBuilder {
 // AKAMAI_CLIENT_SECRET
 // AKAMAI_ACCESS_TOKEN
 //
+// Optionally AKAMAI_MAX_BODY, AKAMAI_HEADERS_TO_SIGN and AKAMAI_ACCOUNT_KEY can be set as well.
+//
 // Example of using the environment variable credentials.
 //
 //     credValue, err  := credentials.NewEnvCredentials().FromEnv()
@@ -114,15 +196,24 @@ func NewCredentials() *CredentialsBuilder {
 //         // handle error
 //     }
 func (ea *CredentialsBuilder) FromEnv() (*Credentials, error) {
+	return ea.FromEnvSection("default")
+}
+
+// FromEnvSection Retrieves credentials of given section from env variables prefixed
+// with 'AKAMAI_{SECTION}_' i.e. AKAMAI_CCU_HOST for section `ccu`. Section is upper
+// cased, dashes and dots are replaced with underscores. Variables of `default`
+// section are prefixed only with 'AKAMAI_', see FromEnv.
+func (ea *CredentialsBuilder) FromEnvSection(section string) (*Credentials, error) {
 	e := ErrorCredentials{}
 
-	log.Debugln("Loading credentials from environment variables")
+	prefix := envPrefix(section)
+
+	ea.logger.Debugf("Loading credentials from environment variables %s*", prefix)
 	var (
 		requiredOptions = []string{"HOST", "CLIENT_TOKEN", "CLIENT_SECRET", "ACCESS_TOKEN"}
 		missing         []string
 	)
 
-	prefix := "AKAMAI_"
 	envCredentials := &Credentials{}
 
 	for _, opt := range requiredOptions {
@@ -146,21 +237,42 @@ func (ea *CredentialsBuilder) FromEnv() (*Credentials, error) {
 	if len(missing) > 0 {
 		e.ErrorMessage = fmt.Sprintf("Missing required environment variables: %s", missing)
 		e.ErrorType = "ErrorCredentialsMissingField"
-		// log.Debuglnln(e.ErrorMessage)
 
 		return nil, e
 	}
 
+	if val, ok := os.LookupEnv(prefix + "MAX_BODY"); ok {
+		maxBody, err := strconv.Atoi(val)
+		if err != nil {
+			e.ErrorMessage = fmt.Sprintf("Environment variable %sMAX_BODY is not a number: %s", prefix, val)
+			e.ErrorType = "ErrorCredentialValidation"
+
+			return nil, e
+		}
+		envCredentials.MaxBody = maxBody
+	}
+
+	if val, ok := os.LookupEnv(prefix + "HEADERS_TO_SIGN"); ok {
+		for _, h := range strings.Split(val, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				envCredentials.HeadersToSign = append(envCredentials.HeadersToSign, h)
+			}
+		}
+	}
+
+	if val, ok := os.LookupEnv(prefix + "ACCOUNT_KEY"); ok {
+		envCredentials.AccountKey = strings.TrimSpace(val)
+	}
+
 	result, err := govalidator.ValidateStruct(envCredentials)
 	if err != nil {
 		e.ErrorMessage = fmt.Sprintf("Environment variables are not correct: %s", err.Error())
 		e.ErrorType = "ErrorCredentialValidation"
-		// log.Debuglnln(e.ErrorMessage)
 
 		return nil, e
 	}
 
-	log.Debugf("Credentials from environment variables validated to: %v", result)
+	ea.logger.Debugf("Credentials from environment variables validated to: %v", result)
 
 	return envCredentials, nil
 }
@@ -173,7 +285,7 @@ func (ea *CredentialsBuilder) FromEnv() (*Credentials, error) {
 // }
 func (ea *CredentialsBuilder) FromJSON(json string) (*Credentials, error) {
 	e := ErrorCredentials{}
-	log.Debugln("Loading credentials from JSON string")
+	ea.logger.Debugf("Loading credentials from JSON string")
 
 	credentials := &Credentials{}
 	gojsonq.New().FromString(json).Out(credentials)
@@ -182,12 +294,12 @@ func (ea *CredentialsBuilder) FromJSON(json string) (*Credentials, error) {
 	if err != nil {
 		e.ErrorMessage = fmt.Sprintf("JSON credentials are not correct: %s", err.Error())
 		e.ErrorType = "ErrorCredentialValidation"
-		log.Error(e.ErrorMessage)
+		ea.logger.Errorf("%s", e.ErrorMessage)
 
 		return nil, e
 	}
 
-	log.Debugf("Credentials from JSON validated to: %v", result)
+	ea.logger.Debugf("Credentials from JSON validated to: %v", result)
 
 	return credentials, nil
 }
@@ -200,7 +312,7 @@ func (ea *CredentialsBuilder) FromJSON(json string) (*Credentials, error) {
 // 	}
 func (ea *CredentialsBuilder) FromFile(fileName string) *CredentialsBuilder {
 	ea.edgercFile = fileName
-	log.Debugf("Set file name for retrieval: %s", fileName)
+	ea.logger.Debugf("Set file name for retrieval: %s", fileName)
 
 	return ea
 }
@@ -211,9 +323,7 @@ func (ea *CredentialsBuilder) Section(section string) (*Credentials, error) {
 
 	ea.edgercSection = section
 
-	log.WithFields(log.Fields{
-		"file": ea.edgercFile,
-	}).Debugln("Loading credentials file")
+	ea.logger.Debugf("Loading credentials file: %s", ea.edgercFile)
 
 	edgerc, err := ini.Load(ea.edgercFile)
 	if err != nil {
@@ -223,36 +333,37 @@ func (ea *CredentialsBuilder) Section(section string) (*Credentials, error) {
 		return nil, e
 	}
 
-	log.WithFields(log.Fields{
-		"section": section,
-	}).Debugln("Loading section from credentials file")
+	ea.logger.Debugf("Loading section from credentials file: %s", section)
 
-	sectionNames := edgerc.SectionStrings()
+	credentials, err := sectionCredentials(edgerc, ea.edgercFile, ea.edgercSection)
+	if err != nil {
+		return nil, err
+	}
 
-	if !(stringInSlice(ea.edgercSection, sectionNames)) {
-		e.ErrorMessage = fmt.Sprintf("%s", "Could not find respective section in credentials file")
-		e.ErrorType = "ErrorCredentialSection"
+	ea.logger.Debugf("Credentials from file validated")
+	return credentials, nil
 
-		return nil, e
+}
+
+// sectionCredentials maps section of loaded credentials file to validated credentials
+func sectionCredentials(edgerc *ini.File, path, section string) (*Credentials, error) {
+	e := ErrorCredentials{}
+
+	if !(stringInSlice(section, edgerc.SectionStrings())) {
+		return nil, errSectionNotFound(section, path)
 	}
 
-	log.Debugln("Create & map credentials object")
 	credentials := &Credentials{}
-	edgerc.Section(ea.edgercSection).MapTo(credentials)
+	edgerc.Section(section).MapTo(credentials)
 
-	log.Debugln("Validating credentials")
-
-	result, err := govalidator.ValidateStruct(credentials)
-	if err != nil {
+	if _, err := govalidator.ValidateStruct(credentials); err != nil {
 		e.ErrorMessage = fmt.Sprintf("JSON credentials are not correct: %s", err.Error())
 		e.ErrorType = "ErrorCredentialValidation"
 
 		return nil, e
 	}
 
-	log.Debugf(fmt.Sprintf("Credentials from file validated to: %v", result))
 	return credentials, nil
-
 }
 
 //stringInSlice is a private helper for string operations.
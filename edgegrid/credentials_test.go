@@ -0,0 +1,376 @@
+package edgegrid
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testEdgerc = `[default]
+host = akab-default.luna.akamaiapis.net
+client_token = akab-client-default
+client_secret = secret-default
+access_token = akab-access-default
+
+[ccu]
+host = akab-ccu.luna.akamaiapis.net
+client_token = akab-client-ccu
+client_secret = secret-ccu
+access_token = akab-access-ccu
+`
+
+// setEnv sets environment variable for the duration of the test
+func setEnv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// clearCredentialsEnv removes variables read by AutoLoad and sets empty home directory
+func clearCredentialsEnv(t *testing.T) string {
+	home := t.TempDir()
+	setEnv(t, "HOME", home)
+
+	for _, key := range []string{
+		string(EnvVarEdgercPath), string(EnvVarEdgercSection),
+		"AKAMAI_HOST", "AKAMAI_CLIENT_TOKEN", "AKAMAI_CLIENT_SECRET", "AKAMAI_ACCESS_TOKEN",
+		"AKAMAI_MAX_BODY", "AKAMAI_HEADERS_TO_SIGN", "AKAMAI_ACCOUNT_KEY",
+		"AKAMAI_CCU_HOST", "AKAMAI_CCU_CLIENT_TOKEN", "AKAMAI_CCU_CLIENT_SECRET", "AKAMAI_CCU_ACCESS_TOKEN",
+		"AKAMAI_CCU_ACCOUNT_KEY",
+	} {
+		setEnv(t, key, "")
+		os.Unsetenv(key)
+	}
+
+	return home
+}
+
+func TestFromEnvSection(t *testing.T) {
+	clearCredentialsEnv(t)
+
+	setEnv(t, "AKAMAI_CCU_HOST", "akab-ccu.luna.akamaiapis.net")
+	setEnv(t, "AKAMAI_CCU_CLIENT_TOKEN", "akab-client-ccu")
+	setEnv(t, "AKAMAI_CCU_CLIENT_SECRET", "secret-ccu")
+	setEnv(t, "AKAMAI_CCU_ACCESS_TOKEN", "akab-access-ccu")
+
+	creds, err := NewCredentials().FromEnvSection("ccu")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-ccu.luna.akamaiapis.net", creds.Host)
+		assert.Empty(t, creds.AccountKey)
+	}
+
+	setEnv(t, "AKAMAI_CCU_ACCOUNT_KEY", "1-ABCD")
+
+	creds, err = NewCredentials().FromEnvSection("ccu")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1-ABCD", creds.AccountKey)
+	}
+
+	_, err = NewCredentials().FromEnv()
+	assert.Error(t, err)
+
+	setEnv(t, "AKAMAI_HOST", "akab-default.luna.akamaiapis.net")
+	setEnv(t, "AKAMAI_CLIENT_TOKEN", "akab-client-default")
+	setEnv(t, "AKAMAI_CLIENT_SECRET", "secret-default")
+	setEnv(t, "AKAMAI_ACCESS_TOKEN", "akab-access-default")
+	setEnv(t, "AKAMAI_MAX_BODY", "8192")
+	setEnv(t, "AKAMAI_HEADERS_TO_SIGN", "X-One, X-Two")
+
+	creds, err = NewCredentials().FromEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 8192, creds.MaxBody)
+		assert.Equal(t, []string{"X-One", "X-Two"}, creds.HeadersToSign)
+	}
+
+	assert.Equal(t, "AKAMAI_PAPI_PROD_", envPrefix("papi-prod"))
+}
+
+func TestAutoLoad(t *testing.T) {
+	home := clearCredentialsEnv(t)
+
+	// Nothing to load from
+	_, err := NewCredentials().AutoLoad("")
+	var credErr ErrorCredentials
+	if assert.True(t, errors.As(err, &credErr)) {
+		assert.Equal(t, "ErrorCredentialsNotFound", credErr.ErrorType)
+		assert.Len(t, credErr.Attempts, 2)
+		assert.Contains(t, err.Error(), "AKAMAI_HOST")
+		assert.Contains(t, err.Error(), filepath.Join(home, ".edgerc"))
+	}
+
+	// ~/.edgerc
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(home, ".edgerc"), []byte(testEdgerc), 0600))
+	creds, err := NewCredentials().AutoLoad("")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-default.luna.akamaiapis.net", creds.Host)
+	}
+
+	// Path and section from environment
+	path := filepath.Join(t.TempDir(), "credentials")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testEdgerc), 0600))
+	setEnv(t, string(EnvVarEdgercPath), path)
+	setEnv(t, string(EnvVarEdgercSection), "ccu")
+
+	creds, err = NewCredentials().AutoLoad("")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-ccu.luna.akamaiapis.net", creds.Host)
+	}
+
+	// Section variables take precedence over file
+	setEnv(t, "AKAMAI_CCU_HOST", "akab-env.luna.akamaiapis.net")
+	setEnv(t, "AKAMAI_CCU_CLIENT_TOKEN", "akab-client-env")
+	setEnv(t, "AKAMAI_CCU_CLIENT_SECRET", "secret-env")
+	setEnv(t, "AKAMAI_CCU_ACCESS_TOKEN", "akab-access-env")
+
+	creds, err = NewCredentials().AutoLoad("")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-env.luna.akamaiapis.net", creds.Host)
+	}
+
+	_, err = NewCredentials().AutoLoad("missing")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `Could not find section "missing"`)
+	}
+}
+
+func TestCredentialsProviders(t *testing.T) {
+	ctx := context.Background()
+	clearCredentialsEnv(t)
+
+	// Secrets directory
+	dir := t.TempDir()
+	for key, value := range map[string]string{
+		"host":            "akab-secret.luna.akamaiapis.net\n",
+		"client_token":    "akab-client-secret",
+		"client_secret":   "secret-secret",
+		"access_token":    "akab-access-secret",
+		"headers_to_sign": "X-One,X-Two",
+		"account_key":     "1-ABCD\n",
+	} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, key), []byte(value), 0600))
+	}
+
+	creds, err := NewSecretsDirCredentialsProvider(dir).Retrieve(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-secret.luna.akamaiapis.net", creds.Host)
+		assert.Equal(t, []string{"X-One", "X-Two"}, creds.HeadersToSign)
+		assert.Equal(t, "1-ABCD", creds.AccountKey)
+	}
+
+	// Chain reports every failed provider
+	path := filepath.Join(t.TempDir(), "credentials")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testEdgerc), 0600))
+
+	chain := NewChainCredentialsProvider(
+		NewEnvCredentialsProvider("ccu"),
+		NewSecretsDirCredentialsProvider(t.TempDir()),
+		NewEdgercCredentialsProvider(path, "ccu"),
+	)
+	creds, err = chain.Retrieve(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "akab-ccu.luna.akamaiapis.net", creds.Host)
+	}
+
+	_, err = NewChainCredentialsProvider(
+		NewEnvCredentialsProvider("ccu"),
+		NewEdgercCredentialsProvider(path, "missing"),
+	).Retrieve(ctx)
+	var credErr ErrorCredentials
+	if assert.True(t, errors.As(err, &credErr)) {
+		assert.Len(t, credErr.Attempts, 2)
+		assert.Equal(t, "environment variables AKAMAI_CCU_*", credErr.Attempts[0].Source)
+		assert.Equal(t, "edgerc file "+path, credErr.Attempts[1].Source)
+	}
+
+	// Command printing JSON
+	if _, err := exec.LookPath("echo"); err == nil {
+		creds, err = NewExecCredentialsProvider("echo", `{ "client_secret": "x", "host": "akab-exec.luna.akamaiapis.net", "access_token": "z", "client_token": "b" }`).Retrieve(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "akab-exec.luna.akamaiapis.net", creds.Host)
+		}
+	}
+
+	_, err = NewExecCredentialsProvider(filepath.Join(dir, "missing-command")).Retrieve(ctx)
+	assert.Error(t, err)
+}
+
+func TestCachingCredentialsProvider(t *testing.T) {
+	var calls int
+	provider := NewCachingCredentialsProvider(CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		calls++
+		if calls == 3 {
+			return nil, errors.New("unavailable")
+		}
+		return &Credentials{Host: "akab-cached.luna.akamaiapis.net"}, nil
+	}), 20*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		_, err := provider.Retrieve(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls, "Credentials should be cached")
+
+	time.Sleep(30 * time.Millisecond)
+	_, err := provider.Retrieve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls, "Expired credentials should be retrieved again")
+
+	provider.Expire()
+	_, err = provider.Retrieve(context.Background())
+	assert.EqualError(t, err, "unavailable")
+
+	_, err = provider.Retrieve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+// writeEdgerc writes file and moves its modification time so change is always noticed
+func writeEdgerc(t *testing.T, path, content string, modTime time.Time) {
+	// File is replaced at once so that watcher never reads it half written
+	tmp := path + ".tmp"
+	assert.NoError(t, ioutil.WriteFile(tmp, []byte(content), 0600))
+	assert.NoError(t, os.Chtimes(tmp, modTime, modTime))
+	assert.NoError(t, os.Rename(tmp, path))
+}
+
+func TestCredentialsWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	now := time.Now()
+	writeEdgerc(t, path, testEdgerc, now)
+
+	reloads := make(chan *Credentials, 1)
+	errs := make(chan error, 1)
+
+	watcher, err := NewCredentials().FromFile(path).Watch(context.Background(), "ccu", ReloadOptions{
+		Interval: 5 * time.Millisecond,
+		OnReload: func(c *Credentials) { reloads <- c },
+		OnError:  func(err error) { errs <- err },
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer watcher.Close()
+
+	creds, _ := watcher.Retrieve(context.Background())
+	assert.Equal(t, "akab-client-ccu", creds.ClientToken)
+
+	// Rotated credentials replace previous ones
+	writeEdgerc(t, path, strings.Replace(testEdgerc, "akab-client-ccu", "akab-client-rotated", 1), now.Add(time.Second))
+	select {
+	case c := <-reloads:
+		assert.Equal(t, "akab-client-rotated", c.ClientToken)
+		assert.Equal(t, "akab-client-rotated", watcher.Credentials().ClientToken)
+	case <-time.After(time.Second):
+		t.Fatal("Credentials should be reloaded")
+	}
+
+	// Same length content written within mtime granularity is detected too
+	writeEdgerc(t, path, strings.Replace(testEdgerc, "akab-client-ccu", "akab-client-rotatex", 1), now.Add(time.Second))
+	select {
+	case c := <-reloads:
+		assert.Equal(t, "akab-client-rotatex", c.ClientToken)
+	case <-time.After(time.Second):
+		t.Fatal("Credentials of the same length should be reloaded")
+	}
+
+	// Broken file is reported and previous credentials are kept
+	writeEdgerc(t, path, "[default]\nhost = akab-default.luna.akamaiapis.net\n", now.Add(2*time.Second))
+	select {
+	case err := <-errs:
+		var credErr ErrorCredentials
+		if assert.True(t, errors.As(err, &credErr)) {
+			assert.Equal(t, "ErrorCredentialsReload", credErr.ErrorType)
+			assert.Contains(t, err.Error(), `Could not find section "ccu"`)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Reload failure should be reported")
+	}
+	assert.Equal(t, "akab-client-rotatex", watcher.Credentials().ClientToken)
+
+	_, err = NewCredentials().FromFile(path).Watch(context.Background(), "ccu", ReloadOptions{})
+	assert.Error(t, err, "Invalid file should not be watched")
+}
+
+func TestEdgercFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	writeEdgerc(t, path, "; Managed by provisioning\n"+testEdgerc+"\n# Team A\n[team-a]\nhost = akab-a.luna.akamaiapis.net\nclient_token = akab-client-a\nclient_secret = secret-a\naccess_token = akab-access-a\nmax_body = 8192\n", time.Now())
+	assert.NoError(t, os.Chmod(path, 0644))
+
+	edgerc, err := OpenEdgerc(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"default", "ccu", "team-a"}, edgerc.Sections())
+
+	creds := &Credentials{
+		Host:          "akab-b.luna.akamaiapis.net",
+		ClientToken:   "akab-client-b",
+		ClientSecret:  "secret+b/with=padding==",
+		AccessToken:   "akab-access-b",
+		HeadersToSign: []string{"X-One", "X-Two"},
+		AccountKey:    "1-ABCD",
+	}
+	assert.NoError(t, edgerc.AddSection("team-b", creds))
+	assert.Error(t, edgerc.AddSection("team-b", creds), "Existing section should not be added")
+	assert.Error(t, edgerc.AddSection("team-c", &Credentials{Host: "akab-c.luna.akamaiapis.net"}))
+
+	// Update keeps comments, optional keys which are not set are removed
+	update := *creds
+	update.AccountKey = ""
+	assert.NoError(t, edgerc.UpdateSection("team-a", &update))
+	assert.Error(t, edgerc.UpdateSection("missing", creds))
+
+	assert.NoError(t, edgerc.RemoveSection("ccu"))
+	assert.Error(t, edgerc.RemoveSection("ccu"))
+
+	assert.NoError(t, edgerc.Save())
+
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Contains(t, string(content), "; Managed by provisioning")
+	assert.Contains(t, string(content), "# Team A\n[team-a]")
+	assert.NotContains(t, string(content), "max_body")
+
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	assert.Len(t, files, 1, "Temporary file should be removed")
+
+	edgerc, err = OpenEdgerc(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"default", "team-a", "team-b"}, edgerc.Sections())
+	}
+
+	saved, err := NewCredentials().FromFile(path).Section("team-b")
+	if assert.NoError(t, err) {
+		assert.Equal(t, creds, saved)
+	}
+
+	// New file is created
+	edgerc, err = OpenEdgerc(filepath.Join(t.TempDir(), ".edgerc"))
+	if assert.NoError(t, err) {
+		assert.Empty(t, edgerc.Sections())
+		assert.NoError(t, edgerc.AddSection("default", creds))
+		assert.NoError(t, edgerc.Save())
+
+		_, err = NewCredentials().FromFile(edgerc.Path()).Section("default")
+		assert.NoError(t, err)
+	}
+}
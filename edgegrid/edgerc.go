@@ -0,0 +1,255 @@
+package edgegrid
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-ini/ini"
+)
+
+// EdgercFile manages sections of .edgerc file. Comments and ordering of
+// sections and keys are preserved when the file is saved.
+type EdgercFile struct {
+	path string
+	file *ini.File
+}
+
+// OpenEdgerc loads .edgerc file for editing. Empty path stands for file set by
+// AKAMAI_EDGERC_CONFIG variable or ~/.edgerc. File which does not exist yet is
+// created by Save.
+//
+//	edgerc, err := edgegrid.OpenEdgerc("")
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := edgerc.AddSection("team-a", creds); err != nil {
+//		return err
+//	}
+//
+//	err = edgerc.Save()
+func OpenEdgerc(path string) (*EdgercFile, error) {
+	if path == "" {
+		var err error
+		if path, err = edgercPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	e := &EdgercFile{path: path}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		e.file = ini.Empty()
+		return e, nil
+	}
+
+	file, err := ini.Load(path)
+	if err != nil {
+		return nil, ErrorCredentials{
+			ErrorMessage: err.Error(),
+			ErrorType:    "ErrorCredentialFile",
+		}
+	}
+	e.file = file
+
+	return e, nil
+}
+
+// Path returns path of the file.
+func (e *EdgercFile) Path() string {
+	return e.path
+}
+
+// Sections returns names of sections in order of the file.
+func (e *EdgercFile) Sections() []string {
+	var names []string
+	for _, s := range e.file.Sections() {
+		if s.Name() == ini.DefaultSection && len(s.Keys()) == 0 {
+			continue
+		}
+		names = append(names, s.Name())
+	}
+
+	return names
+}
+
+// HasSection reports whether section exists.
+func (e *EdgercFile) HasSection(name string) bool {
+	return stringInSlice(name, e.Sections())
+}
+
+// Section returns validated credentials of section.
+func (e *EdgercFile) Section(name string) (*Credentials, error) {
+	return sectionCredentials(e.file, e.path, name)
+}
+
+// AddSection adds section with given credentials at the end of the file.
+// Error is returned when section already exists.
+func (e *EdgercFile) AddSection(name string, creds *Credentials) error {
+	if e.HasSection(name) {
+		return ErrorCredentials{
+			ErrorMessage: fmt.Sprintf("Section %q already exists in credentials file %s", name, e.path),
+			ErrorType:    "ErrorCredentialSection",
+		}
+	}
+
+	if err := validateEdgercCredentials(name, creds); err != nil {
+		return err
+	}
+
+	section, err := e.file.NewSection(name)
+	if err != nil {
+		return err
+	}
+
+	return setSectionKeys(section, creds)
+}
+
+// UpdateSection replaces credentials of existing section. Keys keep their
+// position and comments, optional keys which are not set are removed.
+func (e *EdgercFile) UpdateSection(name string, creds *Credentials) error {
+	if !e.HasSection(name) {
+		return errSectionNotFound(name, e.path)
+	}
+
+	if err := validateEdgercCredentials(name, creds); err != nil {
+		return err
+	}
+
+	return setSectionKeys(e.file.Section(name), creds)
+}
+
+// RemoveSection removes section from the file.
+func (e *EdgercFile) RemoveSection(name string) error {
+	if !e.HasSection(name) {
+		return errSectionNotFound(name, e.path)
+	}
+
+	e.file.DeleteSection(name)
+
+	return nil
+}
+
+// WriteTo writes content of the file to w.
+func (e *EdgercFile) WriteTo(w io.Writer) (int64, error) {
+	return e.file.WriteTo(w)
+}
+
+// Save writes the file atomically with 0600 permissions. Content is written to
+// temporary file in the same directory which then replaces the file.
+func (e *EdgercFile) Save() error {
+	dir, base := filepath.Split(e.path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+base+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	// Temporary file is left only when something failed
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := e.file.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), e.path)
+}
+
+// setSectionKeys sets keys of section to credentials
+func setSectionKeys(section *ini.Section, creds *Credentials) error {
+	var maxBody string
+	if creds.MaxBody > 0 {
+		maxBody = strconv.Itoa(creds.MaxBody)
+	}
+
+	for _, kv := range [][2]string{
+		{"host", creds.Host},
+		{"client_token", creds.ClientToken},
+		{"client_secret", creds.ClientSecret},
+		{"access_token", creds.AccessToken},
+		{"max_body", maxBody},
+		{"headers_to_sign", strings.Join(creds.HeadersToSign, ", ")},
+		{"account_key", creds.AccountKey},
+	} {
+		key, value := kv[0], kv[1]
+
+		switch {
+		case value == "":
+			section.DeleteKey(key)
+		case section.HasKey(key):
+			section.Key(key).SetValue(value)
+		default:
+			if _, err := section.NewKey(key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateEdgercCredentials checks credentials before they are written
+func validateEdgercCredentials(name string, creds *Credentials) error {
+	e := ErrorCredentials{ErrorType: "ErrorCredentialValidation"}
+
+	if strings.TrimSpace(name) == "" || strings.ContainsAny(name, "[]\n") {
+		e.ErrorMessage = fmt.Sprintf("Invalid section name %q", name)
+		return e
+	}
+
+	if creds == nil {
+		e.ErrorMessage = "Credentials are not set"
+		return e
+	}
+
+	var missing []string
+	for _, kv := range [][2]string{
+		{"host", creds.Host},
+		{"client_token", creds.ClientToken},
+		{"client_secret", creds.ClientSecret},
+		{"access_token", creds.AccessToken},
+	} {
+		if strings.TrimSpace(kv[1]) == "" {
+			missing = append(missing, kv[0])
+		}
+	}
+
+	if len(missing) > 0 {
+		e.ErrorMessage = fmt.Sprintf("Credentials of section %q miss required keys: %s", name, missing)
+		e.ErrorType = "ErrorCredentialsMissingField"
+		return e
+	}
+
+	return nil
+}
+
+// errSectionNotFound returns error of missing section
+func errSectionNotFound(section, path string) error {
+	return ErrorCredentials{
+		ErrorMessage: fmt.Sprintf("Could not find section %q in credentials file %s", section, path),
+		ErrorType:    "ErrorCredentialSection",
+	}
+}
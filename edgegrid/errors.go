@@ -0,0 +1,188 @@
+package edgegrid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors which can be matched with errors.Is against
+// errors returned by service clients.
+//
+//	_, err := svc.GetNetworkList("123_LIST", opts)
+//	if errors.Is(err, edgegrid.ErrNotFound) {
+//		// handle missing list
+//	}
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrConflict     = errors.New("conflict")
+)
+
+// Error represents RFC 7807 problem details returned by Akamai APIs.
+// Service specific error ( i.e. netlistv2.NetworkListErrorv2 ) is available
+// through errors.As.
+type Error struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+
+	// RequestID, IncidentID and SupportID identify failed request when contacting support
+	RequestID  string
+	IncidentID string
+	SupportID  string
+
+	// FieldErrors lists validation problems of request fields
+	FieldErrors []FieldError
+
+	// RawBody holds response body as received
+	RawBody []byte
+
+	// Err is the service specific error
+	Err error
+}
+
+// FieldError represents validation problem of a single request field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// problem represents superset of error fields used across Akamai APIs
+type problem struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Detail      string `json:"detail"`
+	Instance    string `json:"instance"`
+	RequestID   string `json:"requestId"`
+	IncidentID  string `json:"incidentId"`
+	SupportID   string `json:"supportId"`
+	DescribedBy string `json:"describedBy"`
+	Errors      []struct {
+		FieldName string `json:"fieldName"`
+		Field     string `json:"field"`
+		Error     string `json:"error"`
+		Message   string `json:"message"`
+		Detail    string `json:"detail"`
+		Title     string `json:"title"`
+	} `json:"errors"`
+	Details []struct {
+		Field   string `json:"field"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"details"`
+	FieldErrors struct {
+		Entry []struct {
+			Key   string   `json:"key"`
+			Value []string `json:"value"`
+		} `json:"entry"`
+	} `json:"fieldErrors"`
+}
+
+// NewError creates Error from API response and its body. Status is always
+// taken from the response as some APIs report it in body as a string
+// or not at all. err is the service specific error, can be nil.
+func NewError(resp *http.Response, body []byte, err error) *Error {
+	e := &Error{
+		RawBody: body,
+		Err:     err,
+	}
+
+	if resp != nil {
+		e.Status = resp.StatusCode
+		e.RequestID = resp.Header.Get("X-Request-Id")
+	}
+
+	var p problem
+	if json.Unmarshal(body, &p) != nil {
+		return e
+	}
+
+	e.Type = p.Type
+	if e.Type == "" {
+		e.Type = p.DescribedBy
+	}
+	e.Title = p.Title
+	e.Detail = p.Detail
+	e.Instance = p.Instance
+	e.IncidentID = p.IncidentID
+	e.SupportID = p.SupportID
+	if p.RequestID != "" {
+		e.RequestID = p.RequestID
+	}
+
+	for _, fe := range p.Errors {
+		e.FieldErrors = append(e.FieldErrors, FieldError{
+			Field:   firstNonEmpty(fe.FieldName, fe.Field),
+			Message: firstNonEmpty(fe.Error, fe.Message, fe.Detail, fe.Title),
+		})
+	}
+
+	for _, fe := range p.Details {
+		e.FieldErrors = append(e.FieldErrors, FieldError{
+			Field:   fe.Field,
+			Message: firstNonEmpty(fe.Message, fe.Code),
+		})
+	}
+
+	for _, fe := range p.FieldErrors.Entry {
+		e.FieldErrors = append(e.FieldErrors, FieldError{
+			Field:   fe.Key,
+			Message: strings.Join(fe.Value, ", "),
+		})
+	}
+
+	return e
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e *Error) Error() string {
+	title := e.Title
+	if title == "" {
+		title = http.StatusText(e.Status)
+	}
+
+	msg := fmt.Sprintf("%d %s", e.Status, title)
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+
+	return msg
+}
+
+// Unwrap returns the service specific error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether error matches one of sentinel errors based on HTTP status.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.Status == http.StatusConflict
+	}
+
+	return false
+}
+
+// firstNonEmpty is a private helper returning first non empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package edgegrid
+
+import "net/http"
+
+// RequestInterceptor is called for every request just before it is signed,
+// so headers it sets can be part of the signature. Request context is
+// available with req.Context(). Returning error aborts the request and
+// the error is returned to the caller.
+//
+// Interceptor reading the body has to restore it for the request to be sent.
+type RequestInterceptor func(req *http.Request) error
+
+// ResponseInterceptor is called for every response received from Akamai
+// including error responses. Request which produced the response is available
+// with resp.Request and the body can be read again. Returning error makes the
+// call fail with that error.
+type ResponseInterceptor func(resp *http.Response) error
@@ -0,0 +1,23 @@
+// Package logadapter provides adapters which allow popular logging
+// libraries to be used as edgegrid.Logger.
+//
+//	cfg := edgegrid.NewConfig().
+//		WithCredentials(creds).
+//		WithLogger(logadapter.NewZap(zapLogger))
+package logadapter
+
+import (
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+// NewLogrus returns edgegrid.Logger writing to given logrus logger or entry.
+func NewLogrus(logger logrus.FieldLogger) edgegrid.Logger {
+	return logger
+}
+
+// NewZap returns edgegrid.Logger writing to given zap logger.
+func NewZap(logger *zap.Logger) edgegrid.Logger {
+	return logger.Sugar()
+}
@@ -0,0 +1,61 @@
+package logadapter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogrus(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	log := NewLogrus(logger)
+	log.Debugf("debug %d", 1)
+	log.Infof("info %s", "x")
+	log.Warnf("warn %v", true)
+	log.Errorf("error %q", "y")
+
+	entries := hook.AllEntries()
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, logrus.DebugLevel, entries[0].Level)
+		assert.Equal(t, "debug 1", entries[0].Message)
+		assert.Equal(t, logrus.InfoLevel, entries[1].Level)
+		assert.Equal(t, "info x", entries[1].Message)
+		assert.Equal(t, logrus.WarnLevel, entries[2].Level)
+		assert.Equal(t, "warn true", entries[2].Message)
+		assert.Equal(t, logrus.ErrorLevel, entries[3].Level)
+		assert.Equal(t, `error "y"`, entries[3].Message)
+	}
+}
+
+func TestZap(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	log := NewZap(zap.New(core, zap.AddCaller()))
+	log.Debugf("debug %d", 1)
+	log.Infof("info %s", "x")
+	log.Warnf("warn %v", true)
+	log.Errorf("error %q", "y")
+
+	entries := logs.AllUntimed()
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+		assert.Equal(t, "debug 1", entries[0].Message)
+		assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+		assert.Equal(t, "info x", entries[1].Message)
+		assert.Equal(t, zapcore.WarnLevel, entries[2].Level)
+		assert.Equal(t, "warn true", entries[2].Message)
+		assert.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+		assert.Equal(t, `error "y"`, entries[3].Message)
+
+		// Entries are attributed to the real call site
+		assert.Equal(t, "logadapter_test.go", filepath.Base(entries[0].Caller.File))
+	}
+}
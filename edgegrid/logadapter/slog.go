@@ -0,0 +1,46 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+)
+
+// slogLogger formats messages and passes them to slog.Logger
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlog returns edgegrid.Logger writing to given slog logger.
+func NewSlog(logger *slog.Logger) edgegrid.Logger {
+	return &slogLogger{logger: logger}
+}
+
+func (l *slogLogger) log(level slog.Level, format string, v ...interface{}) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, fmt.Sprintf(format, v...))
+}
+
+func (l *slogLogger) Debugf(format string, v ...interface{}) {
+	l.log(slog.LevelDebug, format, v...)
+}
+
+func (l *slogLogger) Infof(format string, v ...interface{}) {
+	l.log(slog.LevelInfo, format, v...)
+}
+
+func (l *slogLogger) Warnf(format string, v ...interface{}) {
+	l.log(slog.LevelWarn, format, v...)
+}
+
+func (l *slogLogger) Errorf(format string, v ...interface{}) {
+	l.log(slog.LevelError, format, v...)
+}
@@ -0,0 +1,50 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	log := NewSlog(slog.New(handler))
+	log.Debugf("debug %d", 1)
+	log.Infof("info %s", "x")
+	log.Warnf("warn %v", true)
+	log.Errorf("error %q", "y")
+
+	assert.Equal(t, []string{
+		`level=DEBUG msg="debug 1"`,
+		`level=INFO msg="info x"`,
+		`level=WARN msg="warn true"`,
+		`level=ERROR msg="error \"y\""`,
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
+
+func TestSlogLevel(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+
+	log := NewSlog(slog.New(handler))
+	log.Debugf("debug")
+	log.Infof("info")
+
+	assert.Empty(t, buf.String())
+}
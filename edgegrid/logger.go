@@ -0,0 +1,43 @@
+package edgegrid
+
+import (
+	"io/ioutil"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is the interface used by the library to output log messages.
+// It is compatible with logger used by underlying http client, so the
+// same instance is used for request debug output.
+//
+// Adapters for logrus, zap and log/slog are available in the
+// edgegrid/logadapter package.
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+}
+
+// NewDefaultLogger returns logger used when none is defined in Config.
+// It writes to stderr using dedicated logrus instance with given
+// verbosity ( debug/info/warn/error ), global logrus logger is not modified.
+func NewDefaultLogger(verbosity string) Logger {
+	logger := logrus.New()
+
+	level, err := logrus.ParseLevel(verbosity)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	logger.SetLevel(level)
+
+	return logger
+}
+
+// NewNopLogger returns logger which discards all messages.
+func NewNopLogger() Logger {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return logger
+}
@@ -0,0 +1,364 @@
+package edgegrid
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// CredentialsProvider supplies credentials used to sign requests. Service
+// clients ask provider for credentials every time request is signed.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is a function implementing CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Retrieve calls the function.
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentialsProvider always returns the same credentials.
+type StaticCredentialsProvider struct {
+	creds *Credentials
+}
+
+// NewStaticCredentialsProvider returns provider of given credentials.
+func NewStaticCredentialsProvider(creds *Credentials) *StaticCredentialsProvider {
+	return &StaticCredentialsProvider{creds: creds}
+}
+
+// Retrieve returns the credentials.
+func (p *StaticCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	if p.creds == nil {
+		return nil, ErrorCredentials{
+			ErrorMessage: "Static credentials are not set",
+			ErrorType:    "ErrorCredentialsMissing",
+		}
+	}
+	return p.creds, nil
+}
+
+func (p *StaticCredentialsProvider) String() string {
+	return "static credentials"
+}
+
+// EnvCredentialsProvider reads credentials of section from environment variables, see FromEnvSection.
+type EnvCredentialsProvider struct {
+	section string
+	logger  Logger
+}
+
+// NewEnvCredentialsProvider returns provider reading environment variables of given section.
+func NewEnvCredentialsProvider(section string) *EnvCredentialsProvider {
+	return &EnvCredentialsProvider{section: section, logger: NewNopLogger()}
+}
+
+// Retrieve reads the environment variables.
+func (p *EnvCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	return NewCredentials().WithLogger(p.logger).FromEnvSection(p.section)
+}
+
+func (p *EnvCredentialsProvider) String() string {
+	return fmt.Sprintf("environment variables %s*", envPrefix(p.section))
+}
+
+// EdgercCredentialsProvider reads credentials from section of .edgerc file.
+type EdgercCredentialsProvider struct {
+	path    string
+	section string
+	logger  Logger
+}
+
+// NewEdgercCredentialsProvider returns provider reading section of .edgerc file. Empty path
+// stands for file set by AKAMAI_EDGERC_CONFIG variable or ~/.edgerc, empty section for `default`.
+func NewEdgercCredentialsProvider(path, section string) *EdgercCredentialsProvider {
+	if section == "" {
+		section = "default"
+	}
+	return &EdgercCredentialsProvider{path: path, section: section, logger: NewNopLogger()}
+}
+
+// Retrieve reads the file.
+func (p *EdgercCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	path, err := p.resolvePath()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCredentials().WithLogger(p.logger).FromFile(path).Section(p.section)
+}
+
+// resolvePath returns path of the file
+func (p *EdgercCredentialsProvider) resolvePath() (string, error) {
+	if p.path != "" {
+		return p.path, nil
+	}
+	return edgercPath()
+}
+
+func (p *EdgercCredentialsProvider) String() string {
+	path, err := p.resolvePath()
+	if err != nil {
+		path = "~/.edgerc"
+	}
+	return fmt.Sprintf("edgerc file %s", path)
+}
+
+// JSONCredentialsProvider parses credentials from JSON string, see FromJSON.
+type JSONCredentialsProvider struct {
+	json string
+}
+
+// NewJSONCredentialsProvider returns provider of credentials in JSON string.
+func NewJSONCredentialsProvider(json string) *JSONCredentialsProvider {
+	return &JSONCredentialsProvider{json: json}
+}
+
+// Retrieve parses the JSON.
+func (p *JSONCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	return NewCredentials().FromJSON(p.json)
+}
+
+func (p *JSONCredentialsProvider) String() string {
+	return "JSON credentials"
+}
+
+// ExecCredentialsProvider runs command which prints credentials as JSON to standard output.
+type ExecCredentialsProvider struct {
+	command string
+	args    []string
+}
+
+// NewExecCredentialsProvider returns provider running given command i.e. secret manager CLI.
+func NewExecCredentialsProvider(command string, args ...string) *ExecCredentialsProvider {
+	return &ExecCredentialsProvider{command: command, args: args}
+}
+
+// Retrieve runs the command and parses its output.
+func (p *ExecCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	out, err := exec.CommandContext(ctx, p.command, p.args...).Output()
+	if err != nil {
+		msg := err.Error()
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			msg = fmt.Sprintf("%s: %s", msg, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return nil, ErrorCredentials{
+			ErrorMessage: fmt.Sprintf("Credentials command %s failed: %s", p.command, msg),
+			ErrorType:    "ErrorCredentialsCommand",
+		}
+	}
+
+	return NewCredentials().FromJSON(string(out))
+}
+
+func (p *ExecCredentialsProvider) String() string {
+	return fmt.Sprintf("command %s", strings.Join(append([]string{p.command}, p.args...), " "))
+}
+
+// SecretsDirCredentialsProvider reads credentials from directory with file per key
+// ( `host`, `client_token`, `client_secret`, `access_token` and optional `max_body`,
+// `headers_to_sign` and `account_key` ) as secrets are mounted by container orchestrators.
+type SecretsDirCredentialsProvider struct {
+	dir string
+}
+
+// NewSecretsDirCredentialsProvider returns provider reading files of given directory.
+func NewSecretsDirCredentialsProvider(dir string) *SecretsDirCredentialsProvider {
+	return &SecretsDirCredentialsProvider{dir: dir}
+}
+
+// Retrieve reads the files.
+func (p *SecretsDirCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	e := ErrorCredentials{}
+
+	var (
+		requiredKeys = []string{"host", "client_token", "client_secret", "access_token"}
+		optionalKeys = []string{"max_body", "headers_to_sign", "account_key"}
+		values       = map[string]string{}
+		missing      []string
+	)
+
+	for _, key := range append(requiredKeys, optionalKeys...) {
+		b, err := ioutil.ReadFile(filepath.Join(p.dir, key))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			e.ErrorMessage = err.Error()
+			e.ErrorType = "ErrorCredentialFile"
+
+			return nil, e
+		}
+		values[key] = strings.TrimSpace(string(b))
+	}
+
+	for _, key := range requiredKeys {
+		if values[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		e.ErrorMessage = fmt.Sprintf("Missing required secrets in %s: %s", p.dir, missing)
+		e.ErrorType = "ErrorCredentialsMissingField"
+
+		return nil, e
+	}
+
+	creds := &Credentials{
+		Host:         values["host"],
+		ClientToken:  values["client_token"],
+		ClientSecret: values["client_secret"],
+		AccessToken:  values["access_token"],
+		AccountKey:   values["account_key"],
+	}
+
+	if v, ok := values["max_body"]; ok {
+		maxBody, err := strconv.Atoi(v)
+		if err != nil {
+			e.ErrorMessage = fmt.Sprintf("Secret max_body is not a number: %s", v)
+			e.ErrorType = "ErrorCredentialValidation"
+
+			return nil, e
+		}
+		creds.MaxBody = maxBody
+	}
+
+	if v, ok := values["headers_to_sign"]; ok {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				creds.HeadersToSign = append(creds.HeadersToSign, h)
+			}
+		}
+	}
+
+	if _, err := govalidator.ValidateStruct(creds); err != nil {
+		e.ErrorMessage = fmt.Sprintf("Secrets in %s are not correct: %s", p.dir, err.Error())
+		e.ErrorType = "ErrorCredentialValidation"
+
+		return nil, e
+	}
+
+	return creds, nil
+}
+
+func (p *SecretsDirCredentialsProvider) String() string {
+	return fmt.Sprintf("secrets directory %s", p.dir)
+}
+
+// ChainCredentialsProvider returns credentials of the first provider which succeeds.
+type ChainCredentialsProvider struct {
+	providers []CredentialsProvider
+}
+
+// NewChainCredentialsProvider returns provider trying given providers in order.
+//
+//	provider := edgegrid.NewChainCredentialsProvider(
+//		edgegrid.NewEnvCredentialsProvider("ccu"),
+//		edgegrid.NewSecretsDirCredentialsProvider("/var/run/secrets/akamai"),
+//		edgegrid.NewEdgercCredentialsProvider("", "ccu"),
+//	)
+func NewChainCredentialsProvider(providers ...CredentialsProvider) *ChainCredentialsProvider {
+	return &ChainCredentialsProvider{providers: providers}
+}
+
+// Retrieve tries providers in order. Error lists every provider and why it failed.
+func (p *ChainCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	e := ErrorCredentials{
+		ErrorMessage: "Could not load credentials from any provider",
+		ErrorType:    "ErrorCredentialsNotFound",
+	}
+
+	for _, provider := range p.providers {
+		creds, err := provider.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+
+		e.Attempts = append(e.Attempts, CredentialsAttempt{Source: providerName(provider), Err: err})
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, e
+}
+
+func (p *ChainCredentialsProvider) String() string {
+	names := make([]string, len(p.providers))
+	for i, provider := range p.providers {
+		names[i] = providerName(provider)
+	}
+	return fmt.Sprintf("chain of %s", strings.Join(names, ", "))
+}
+
+// CachingCredentialsProvider keeps credentials of another provider and
+// retrieves them again once they expire.
+type CachingCredentialsProvider struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	creds   *Credentials
+	expires time.Time
+}
+
+// NewCachingCredentialsProvider returns provider caching credentials for ttl.
+// Credentials are cached until Expire is called when ttl is not positive.
+func NewCachingCredentialsProvider(provider CredentialsProvider, ttl time.Duration) *CachingCredentialsProvider {
+	return &CachingCredentialsProvider{provider: provider, ttl: ttl}
+}
+
+// Retrieve returns cached credentials or retrieves them when they expired.
+func (p *CachingCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds != nil && (p.ttl <= 0 || time.Now().Before(p.expires)) {
+		return p.creds, nil
+	}
+
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.creds = creds
+	p.expires = time.Now().Add(p.ttl)
+
+	return creds, nil
+}
+
+// Expire drops cached credentials so the next Retrieve reads them again.
+func (p *CachingCredentialsProvider) Expire() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.creds = nil
+}
+
+func (p *CachingCredentialsProvider) String() string {
+	return fmt.Sprintf("cached %s", providerName(p.provider))
+}
+
+// providerName describes provider in errors
+func providerName(provider CredentialsProvider) string {
+	if s, ok := provider.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", provider)
+}
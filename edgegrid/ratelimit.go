@@ -0,0 +1,17 @@
+package edgegrid
+
+import (
+	"context"
+	"net/http"
+)
+
+// RateLimiter throttles requests made towards Akamai APIs so that client
+// stays within API quotas.
+type RateLimiter interface {
+	// Wait blocks until the request is allowed to be sent or context is done.
+	Wait(ctx context.Context) error
+
+	// Update adapts the limiter using the response received from Akamai
+	// i.e. its rate limiting headers or `429 Too Many Requests` status.
+	Update(resp *http.Response)
+}
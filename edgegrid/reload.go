@@ -0,0 +1,182 @@
+package edgegrid
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// defaultReloadInterval is time between checks of watched credentials file
+const defaultReloadInterval = 5 * time.Second
+
+// ReloadOptions configures CredentialsWatcher.
+type ReloadOptions struct {
+	// Interval is time between checks of the file, 5 seconds by default
+	Interval time.Duration
+
+	// OnReload is called with new credentials once they replace previous ones
+	OnReload func(*Credentials)
+
+	// OnError is called when changed file cannot be loaded, previous credentials are kept
+	OnError func(error)
+}
+
+// CredentialsWatcher keeps credentials of .edgerc section up to date with the file.
+// It implements CredentialsProvider so clients sign every request with current credentials.
+type CredentialsWatcher struct {
+	path    string
+	section string
+	opts    ReloadOptions
+	logger  Logger
+
+	// creds holds current *Credentials
+	creds atomic.Value
+
+	// sum is hash of the file content when it was read last time, zero when
+	// file could not be read. Content is compared since rotated credentials
+	// usually have the same length and can be written within mtime granularity.
+	sum [sha256.Size]byte
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Watch loads section of file set with FromFile and reloads it whenever the file changes
+// until context is done or watcher is closed. Changed file is validated the same way as by
+// Section, when it is not valid OnError is called and previous credentials are kept.
+// Requests which are already signed are not affected by reload.
+//
+//	watcher, err := edgegrid.NewCredentials().FromFile("/home/user/.edgerc").Watch(ctx, "ccu", edgegrid.ReloadOptions{
+//		OnError: func(err error) {
+//			log.Println(err)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer watcher.Close()
+//
+//	config := edgegrid.NewConfig().WithCredentialsProvider(watcher)
+func (ea *CredentialsBuilder) Watch(ctx context.Context, section string, opts ReloadOptions) (*CredentialsWatcher, error) {
+	if section == "" {
+		section = ea.edgercSection
+	}
+
+	path := ea.edgercFile
+	if path == "" {
+		var err error
+		if path, err = edgercPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultReloadInterval
+	}
+
+	w := &CredentialsWatcher{
+		path:    path,
+		section: section,
+		opts:    opts,
+		logger:  ea.logger,
+		done:    make(chan struct{}),
+	}
+
+	w.sum = fileSum(path)
+
+	creds, err := NewCredentials().WithLogger(ea.logger).FromFile(path).Section(section)
+	if err != nil {
+		return nil, err
+	}
+	w.creds.Store(creds)
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	go w.run(ctx)
+
+	return w, nil
+}
+
+// Credentials returns current credentials.
+func (w *CredentialsWatcher) Credentials() *Credentials {
+	return w.creds.Load().(*Credentials)
+}
+
+// Retrieve returns current credentials.
+func (w *CredentialsWatcher) Retrieve(ctx context.Context) (*Credentials, error) {
+	return w.Credentials(), nil
+}
+
+// Close stops watching the file. Current credentials are still returned afterwards.
+func (w *CredentialsWatcher) Close() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *CredentialsWatcher) String() string {
+	return fmt.Sprintf("watched edgerc file %s", w.path)
+}
+
+// run checks the file until context is done
+func (w *CredentialsWatcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check reloads credentials when file content changed since it was read last time
+func (w *CredentialsWatcher) check() {
+	// Each state of the file is loaded ( or reported ) only once
+	sum := fileSum(w.path)
+	if sum == w.sum {
+		return
+	}
+	w.sum = sum
+
+	creds, err := NewCredentials().WithLogger(w.logger).FromFile(w.path).Section(w.section)
+	if err != nil {
+		w.logger.Warnf("Could not reload credentials from %s: %s", w.path, err)
+
+		if w.opts.OnError != nil {
+			w.opts.OnError(ErrorCredentials{
+				ErrorMessage: fmt.Sprintf("Could not reload section %q, previous credentials are still used", w.section),
+				ErrorType:    "ErrorCredentialsReload",
+				Attempts:     []CredentialsAttempt{{Source: fmt.Sprintf("edgerc file %s", w.path), Err: err}},
+			})
+		}
+		return
+	}
+
+	if reflect.DeepEqual(creds, w.Credentials()) {
+		return
+	}
+
+	w.creds.Store(creds)
+	w.logger.Infof("Reloaded credentials of section %s from %s", w.section, w.path)
+
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(creds)
+	}
+}
+
+// fileSum returns hash of file content, zero when file cannot be read
+func fileSum(path string) [sha256.Size]byte {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(b)
+}
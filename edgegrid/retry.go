@@ -0,0 +1,147 @@
+package edgegrid
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy defines how requests towards Akamai APIs are retried when
+// they fail with a transient error.
+//
+// Every attempt is prepared and signed from scratch, so each retry is sent
+// with a fresh nonce and timestamp in the `Authorization` header.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a single request,
+	// including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the base wait time used for exponential backoff between attempts.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the wait time between attempts. It also caps the value
+	// taken from the `Retry-After` response header.
+	MaxBackoff time.Duration
+
+	// RespectRetryAfter determines if the `Retry-After` response header
+	// overrides the computed backoff.
+	RespectRetryAfter bool
+
+	// RetryableStatuses lists HTTP status codes which are retried.
+	RetryableStatuses []int
+
+	// RetryableMethods lists HTTP methods which are retried. Requests rejected
+	// with `429 Too Many Requests` are retried regardless of their method as
+	// Akamai did not process them.
+	RetryableMethods []string
+
+	// ShouldRetry if set replaces the status and method predicates.
+	// Either `resp` is nil and `err` holds the transport error or `resp`
+	// holds the received response.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// NewRetryPolicy returns a new RetryPolicy pointer with default values which
+// can be chained with builder methods.
+//
+//	// Retry up to 5 times with backoff starting at 1 second
+//	policy := edgegrid.NewRetryPolicy().WithMaxAttempts(5).WithBackoff(time.Second, time.Minute)
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       3,
+		MinBackoff:        500 * time.Millisecond,
+		MaxBackoff:        30 * time.Second,
+		RespectRetryAfter: true,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+// WithMaxAttempts sets total number of attempts and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithMaxAttempts(attempts int) *RetryPolicy {
+	p.MaxAttempts = attempts
+	return p
+}
+
+// WithBackoff sets minimal and maximal wait time between attempts and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithBackoff(min, max time.Duration) *RetryPolicy {
+	p.MinBackoff = min
+	p.MaxBackoff = max
+	return p
+}
+
+// WithRespectRetryAfter toggles usage of `Retry-After` header and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithRespectRetryAfter(respect bool) *RetryPolicy {
+	p.RespectRetryAfter = respect
+	return p
+}
+
+// WithRetryableStatuses sets HTTP status codes which are retried and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithRetryableStatuses(statuses ...int) *RetryPolicy {
+	p.RetryableStatuses = statuses
+	return p
+}
+
+// WithRetryableMethods sets HTTP methods which are retried and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithRetryableMethods(methods ...string) *RetryPolicy {
+	p.RetryableMethods = methods
+	return p
+}
+
+// WithShouldRetry sets custom retry predicate and returns
+// a RetryPolicy pointer.
+func (p *RetryPolicy) WithShouldRetry(fn func(resp *http.Response, err error) bool) *RetryPolicy {
+	p.ShouldRetry = fn
+	return p
+}
+
+// Retryable reports whether request which ended with given response or
+// transport error should be attempted again.
+func (p *RetryPolicy) Retryable(method string, resp *http.Response, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+
+	if resp == nil {
+		return err != nil && p.retryableMethod(method)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && p.retryableStatus(resp.StatusCode) {
+		return true
+	}
+
+	return p.retryableMethod(method) && p.retryableStatus(resp.StatusCode)
+}
+
+func (p *RetryPolicy) retryableMethod(method string) bool {
+	for _, m := range p.RetryableMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableStatus(status int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -17,21 +17,58 @@ import (
 
 const (
 	moniker string = "EG1-HMAC-SHA256"
+
+	// DefaultMaxBody is the maximum number of body bytes used for
+	// content hash when credentials do not define `max_body`
+	DefaultMaxBody int = 131072
 )
 
+// ErrorSignature represents an error caused during request signing
+type ErrorSignature struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorType    string `json:"error_type"`
+
+	// Err is the underlying error if there was one
+	Err error `json:"-"`
+}
+
+// ErrorSignature implements the error interface.
+func (e ErrorSignature) Error() string {
+	return e.ErrorMessage
+}
+
+// Unwrap returns the underlying error so it can be inspected
+// with errors.Is and errors.As
+func (e ErrorSignature) Unwrap() error {
+	return e.Err
+}
+
 // SignatureRequest represents object which is used to sign request
 type SignatureRequest struct {
-	creds  *edgegrid.Credentials
-	host   string
-	scheme string
+	creds   *edgegrid.Credentials
+	host    string
+	scheme  string
+	maxBody int
+
+	// timestamp and nonce generators, replaced in tests
+	// to produce deterministic signatures
+	timestamp func() string
+	nonce     func() string
 }
 
 //New takes all required parameters and returns the required auth header
 func New(cr *edgegrid.Credentials, scheme, host string) SignatureRequest {
 	signatureRequest := SignatureRequest{
-		creds:  cr,
-		host:   host,
-		scheme: scheme,
+		creds:     cr,
+		host:      host,
+		scheme:    scheme,
+		maxBody:   DefaultMaxBody,
+		timestamp: generateTimestamp,
+		nonce:     generateNonce,
+	}
+
+	if cr != nil && cr.MaxBody > 0 {
+		signatureRequest.maxBody = cr.MaxBody
 	}
 
 	return signatureRequest
@@ -50,10 +87,29 @@ func (m reader) Close() error { return nil }
 // The string returned by this method conforms to the
 // Akamai {OPEN} EdgeGrid Authentication scheme.
 // https://developer.akamai.com/introduction/Client_Auth.html
-func (sr *SignatureRequest) SignRequest(rrq *http.Request, headersToSign []string) string {
+//
+// Headers listed in headersToSign are canonicalized and included in the
+// signature in the given order, headers missing from request are skipped.
+// When headersToSign is empty, `headers_to_sign` from credentials is used.
+// Body is hashed only for POST requests as required by the specification,
+// at most `max_body` bytes of it are taken into account.
+//
+// Returned error is always of ErrorSignature type.
+func (sr *SignatureRequest) SignRequest(rrq *http.Request, headersToSign []string) (string, error) {
+
+	if sr.creds == nil {
+		return "", ErrorSignature{
+			ErrorMessage: "Cannot sign request without credentials",
+			ErrorType:    "ErrorSignatureCredentials",
+		}
+	}
+
+	if len(headersToSign) == 0 {
+		headersToSign = sr.creds.HeadersToSign
+	}
 
-	nonce := generateNonce()
-	timestamp := generateTimestamp()
+	nonce := sr.nonce()
+	timestamp := sr.timestamp()
 
 	var auth bytes.Buffer
 
@@ -66,7 +122,11 @@ func (sr *SignatureRequest) SignRequest(rrq *http.Request, headersToSign []strin
 
 	auth.WriteString(moniker + " " + strings.Join(joinedPairs, ";") + ";")
 
-	dataToSign := generateDataToSign(rrq, auth.String(), []string{})
+	dataToSign, err := generateDataToSign(rrq, auth.String(), headersToSign, sr.maxBody)
+	if err != nil {
+		return "", err
+	}
+
 	signingKey := generateSigningKey(timestamp, sr.creds.ClientSecret)
 
 	signature := concat([]string{
@@ -76,7 +136,7 @@ func (sr *SignatureRequest) SignRequest(rrq *http.Request, headersToSign []strin
 
 	auth.WriteString(signature)
 
-	return auth.String()
+	return auth.String(), nil
 }
 
 // generateTimestamp retrurns timestamp in the
@@ -109,62 +169,84 @@ func base64HmacSha256(message, secret string) string {
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
 
-func generateDataToSign(rrq *http.Request, authHeader string, headersToSign []string) string {
+func generateDataToSign(rrq *http.Request, authHeader string, headersToSign []string, maxBody int) (string, error) {
+
+	contentHash, err := makeContentHash(rrq, maxBody)
+	if err != nil {
+		return "", err
+	}
 
 	var data bytes.Buffer
 	values := []string{
-		rrq.Method,
+		strings.ToUpper(rrq.Method),
 		rrq.URL.Scheme,
 		rrq.Host,
-		urlPathWithQuery(rrq.URL.Path, rrq.URL.RawQuery),
-		"", //TODO: to be implemented - not required in initial stage
-		makeContentHash(rrq),
+		urlPathWithQuery(rrq.URL.EscapedPath(), rrq.URL.RawQuery),
+		canonicalizeHeaders(rrq, headersToSign),
+		contentHash,
 		authHeader,
 	}
 
 	data.WriteString(strings.Join(values, "\t"))
 
-	return data.String()
+	return data.String(), nil
 }
 
+// canonicalizeHeaders returns headers in order defined by headersToSign.
+// Each one is represented as lowercased name and value with trimmed and
+// collapsed whitespaces, separated with tab.
 func canonicalizeHeaders(request *http.Request, headersToSign []string) string {
-	var canonicalized bytes.Buffer
-
-	for key, values := range request.Header {
-		if stringInSlice(key, headersToSign) {
-			canonicalized.WriteString(concat([]string{
-				strings.ToLower(key),
-				":",
-				strings.Join(strings.Fields(values[0]), " "),
-				"\t",
-			}))
+	var canonicalized []string
+
+	for _, key := range headersToSign {
+		values, ok := request.Header[http.CanonicalHeaderKey(key)]
+		if !ok || len(values) == 0 {
+			continue
 		}
+
+		canonicalized = append(canonicalized, concat([]string{
+			strings.ToLower(key),
+			":",
+			strings.Join(strings.Fields(values[0]), " "),
+		}))
 	}
 
-	return canonicalized.String()
+	return strings.Join(canonicalized, "\t")
 }
 
-func makeContentHash(req *http.Request) string {
+func makeContentHash(req *http.Request, maxBody int) (string, error) {
 
-	if req.Method == "POST" {
+	if strings.ToUpper(req.Method) == "POST" {
 		// Make sure we do have body to build content from
 		if req.Body == nil {
-			return ""
+			return "", nil
 		}
 		buf, err := ioutil.ReadAll(req.Body)
 		req.Body.Close() //  must close
 
 		if err != nil {
-			//TODO: log here
-			panic(err)
+			return "", ErrorSignature{
+				ErrorMessage: fmt.Sprintf("Cannot read request body: %s", err.Error()),
+				ErrorType:    "ErrorSignatureBody",
+				Err:          err,
+			}
 		}
 
 		// Correct body setup based on https://github.com/go-resty/resty/issues/252
 		req.Body = ioutil.NopCloser(bytes.NewBuffer(buf))
-		return base64Sha256(buf)
+
+		if len(buf) == 0 {
+			return "", nil
+		}
+
+		if maxBody > 0 && len(buf) > maxBody {
+			buf = buf[:maxBody]
+		}
+
+		return base64Sha256(buf), nil
 	}
 
-	return ""
+	return "", nil
 }
 
 func generateSigningKey(timestamp, clientSecret string) string {
@@ -180,15 +262,6 @@ func urlPathWithQuery(path, queryParams string) string {
 	return path
 }
 
-func stringInSlice(a string, list []string) bool {
-	for _, b := range list {
-		if b == a {
-			return true
-		}
-	}
-	return false
-}
-
 func concat(arr []string) string {
 	var buff bytes.Buffer
 
@@ -0,0 +1,151 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBaseURL   = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+	testTimestamp = "20140321T19:34:21+0000"
+	testNonce     = "nonce-xx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+
+	// testVectors is copy of Akamai's EdgeGrid reference test data
+	// ( github.com/akamai/AkamaiOPEN-edgegrid-golang, Apache License 2.0 )
+	testVectors = "testdata/testdata.json"
+)
+
+// testSigner returns signer set up the same way as reference tests
+func testSigner() SignatureRequest {
+	sr := New(&edgegrid.Credentials{
+		Host:          "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		ClientToken:   "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret:  "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		AccessToken:   "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		MaxBody:       2048,
+		HeadersToSign: []string{"X-Test1", "X-Test2", "X-Test3"},
+	}, "https", "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net")
+
+	sr.timestamp = func() string { return testTimestamp }
+	sr.nonce = func() string { return testNonce }
+
+	return sr
+}
+
+func TestSignRequestVectors(t *testing.T) {
+	var vectors struct {
+		Tests []struct {
+			Name    string `json:"testName"`
+			Request struct {
+				Method  string              `json:"method"`
+				Path    string              `json:"path"`
+				Headers []map[string]string `json:"headers"`
+				Data    string              `json:"data"`
+			} `json:"request"`
+			ExpectedAuthorization string `json:"expectedAuthorization"`
+		} `json:"tests"`
+	}
+
+	b, err := ioutil.ReadFile(testVectors)
+	if err != nil {
+		t.Fatalf("Could not read test vectors: %s", err)
+	}
+	if err := json.Unmarshal(b, &vectors); err != nil {
+		t.Fatalf("Could not parse test vectors: %s", err)
+	}
+	if !assert.Len(t, vectors.Tests, 13) {
+		return
+	}
+
+	baseURL, err := url.Parse(testBaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sr := testSigner()
+
+	for _, tt := range vectors.Tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// Path is set as is like reference tests do, so it is escaped by url package
+			u := *baseURL
+			u.Path = tt.Request.Path
+
+			req, err := http.NewRequest(tt.Request.Method, u.String(), strings.NewReader(tt.Request.Data))
+			assert.NoError(t, err)
+
+			for _, header := range tt.Request.Headers {
+				for key, value := range header {
+					req.Header.Set(key, value)
+				}
+			}
+
+			auth, err := sr.SignRequest(req, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ExpectedAuthorization, auth)
+		})
+	}
+}
+
+func TestSignRequestKeepsBody(t *testing.T) {
+	sr := testSigner()
+	body := strings.Repeat("d", 4096)
+
+	req, err := http.NewRequest("POST", testBaseURL+"/testapi/v1/t3", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	_, err = sr.SignRequest(req, nil)
+	assert.NoError(t, err)
+
+	buf := new(strings.Builder)
+	_, err = io.Copy(buf, req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, body, buf.String())
+}
+
+func TestDefaultMaxBody(t *testing.T) {
+	sr := New(&edgegrid.Credentials{}, "https", "example.com")
+	assert.Equal(t, DefaultMaxBody, sr.maxBody)
+}
+
+// failingReader returns error on every read
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestSignRequestBodyError(t *testing.T) {
+	sr := testSigner()
+
+	req, err := http.NewRequest("POST", testBaseURL+"/testapi/v1/t3", failingReader{})
+	assert.NoError(t, err)
+
+	auth, err := sr.SignRequest(req, nil)
+	assert.Empty(t, auth)
+
+	var signErr ErrorSignature
+	if assert.True(t, errors.As(err, &signErr)) {
+		assert.Equal(t, "ErrorSignatureBody", signErr.ErrorType)
+		assert.EqualError(t, errors.Unwrap(signErr), "connection reset")
+	}
+}
+
+func TestSignRequestWithoutCredentials(t *testing.T) {
+	sr := New(nil, "https", "example.com")
+
+	req, err := http.NewRequest("GET", testBaseURL+"/", nil)
+	assert.NoError(t, err)
+
+	_, err = sr.SignRequest(req, nil)
+	assert.Error(t, err)
+	assert.IsType(t, ErrorSignature{}, err)
+}
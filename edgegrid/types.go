@@ -3,6 +3,7 @@ package edgegrid
 // AkamaiEnvironmentVar represents Akamai's env variables used
 type AkamaiEnvironmentVar string
 
+// Environment variables which configure AutoLoad and NewConfig
 const (
 	EnvVarEdgercPath        AkamaiEnvironmentVar = "AKAMAI_EDGERC_CONFIG"
 	EnvVarEdgercSection     AkamaiEnvironmentVar = "AKAMAI_EDGERC_SECTION"
@@ -1,6 +1,6 @@
 module github.com/apiheat/go-edgegrid/v6
 
-go 1.15
+go 1.20
 
 require (
 	github.com/asaskevich/govalidator v0.0.0-20180315120708-ccb8e960c48f
@@ -9,7 +9,13 @@ require (
 	github.com/jarcoal/httpmock v1.0.4
 	github.com/satori/go.uuid v1.2.0
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.3.0
+	github.com/stretchr/testify v1.8.4
 	github.com/thedevsaddam/gojsonq v1.9.2-0.20200327153058-daf407b8fd5c
+	go.opentelemetry.io/otel v1.21.0
+	go.opentelemetry.io/otel/metric v1.21.0
+	go.opentelemetry.io/otel/sdk v1.21.0
+	go.opentelemetry.io/otel/sdk/metric v1.21.0
+	go.opentelemetry.io/otel/trace v1.21.0
+	go.uber.org/zap v1.21.0
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 )
@@ -1,15 +1,26 @@
 package billingv2
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // ListContractUsage returns billing measures per product in a given contract
 func (bl *Billingv2) ListContractUsage(contractID, productID string, qStringParams map[string]string) (*BillingResp, error) {
+	return bl.ListContractUsageWithContext(context.Background(), contractID, productID, qStringParams)
+}
+
+// ListContractUsageWithContext is the same as ListContractUsage with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (bl *Billingv2) ListContractUsageWithContext(ctx context.Context, contractID, productID string, qStringParams map[string]string) (*BillingResp, error) {
+	ctx = client.WithOperation(ctx, "ListContractUsage")
 	apiURI := fmt.Sprintf("%s/contracts/%s/products/%s/measures", basePath, contractID, productID)
 
 	// Create and execute request
 	resp, err := bl.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(BillingResp{}).
 		SetQueryParams(qStringParams).
 		SetError(BillingErrorv2{}).
@@ -20,9 +31,7 @@ func (bl *Billingv2) ListContractUsage(contractID, productID string, qStringPara
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*BillingErrorv2)
-
-		return nil, fmt.Errorf("Error requesting contract usage per product. code: %s, title: %s", e.Code, e.Title)
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*BillingResp), nil
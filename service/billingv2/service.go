@@ -8,6 +8,13 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/billing-center-api/v2"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "billingv2"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 5
+	defaultRateBurst = 5
 )
 
 // Billingv2 provides the API operation methods for making requests to
@@ -19,20 +26,25 @@ type Billingv2 struct {
 
 // New creates a new instance of the Billingv2 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     Create a Billingv2 client from just a config.
-//     svc := Billingv2.New(myConfig))
-func New(cfgs *edgegrid.Config) *Billingv2 {
+//     svc, err := Billingv2.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Billingv2, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Billingv2 {
-	svc := &Billingv2{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Billingv2, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Billingv2{Client: c}, nil
 }
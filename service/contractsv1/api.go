@@ -1,23 +1,37 @@
 package contractsv1
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
 
 // ListContracts gets the list of contracts that a user has access to.
 // 'depth' returns a specific set of contracts.
 // Select TOP to return only parent contracts or ALL to return both parent and child contracts.
 func (c *Contractsv1) ListContracts(depth ContractsDepth) (*OutputContractIDs, error) {
-	query := map[string]string{}
-	if depth != "" {
-		query["depth"] = string(depth)
+	return c.ListContractsWithContext(context.Background(), depth)
+}
+
+// ListContractsWithContext is the same as ListContracts with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (c *Contractsv1) ListContractsWithContext(ctx context.Context, depth ContractsDepth) (*OutputContractIDs, error) {
+	ctx = client.WithOperation(ctx, "ListContracts")
+
+	query, err := client.QueryParams(listContractsOptions{Depth: depth})
+	if err != nil {
+		return nil, err
 	}
 
 	apiURI := fmt.Sprintf("%s/contracts/identifiers", basePath)
 
 	// Create and execute request
 	resp, err := c.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputContractIDs{}).
 		SetError(ContractsErrorv1{}).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -25,9 +39,7 @@ func (c *Contractsv1) ListContracts(depth ContractsDepth) (*OutputContractIDs, e
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*ContractsErrorv1)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputContractIDs), nil
@@ -42,26 +54,30 @@ func (c *Contractsv1) ListContracts(depth ContractsDepth) (*OutputContractIDs, e
 // To - Ex: 2016-03-31. The end date, in UTC, to use when looking for products associated with a contract.
 //      The search always ends at 23:59:59 UTC of the specified date. The default end date is the current date.
 func (c *Contractsv1) ListProductsPerContract(contractID, from, to string) (*OutputProducts, error) {
-	query := map[string]string{}
+	return c.ListProductsPerContractWithContext(context.Background(), contractID, from, to)
+}
+
+// ListProductsPerContractWithContext is the same as ListProductsPerContract with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (c *Contractsv1) ListProductsPerContractWithContext(ctx context.Context, contractID, from, to string) (*OutputProducts, error) {
+	ctx = client.WithOperation(ctx, "ListProductsPerContract")
 	if contractID == "" {
 		return nil, fmt.Errorf("Missing argument 'contractID'")
 	}
 
 	apiURI := fmt.Sprintf("%s/contracts/%s/products/summaries", basePath, contractID)
 
-	if from != "" {
-		query["from"] = from
-	}
-
-	if to != "" {
-		query["to"] = to
+	query, err := client.QueryParams(productsOptions{From: from, To: to})
+	if err != nil {
+		return nil, err
 	}
 
 	// Create and execute request
 	resp, err := c.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputProducts{}).
 		SetError(ContractsErrorv1{}).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -69,9 +85,7 @@ func (c *Contractsv1) ListProductsPerContract(contractID, from, to string) (*Out
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*ContractsErrorv1)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputProducts), nil
@@ -80,10 +94,18 @@ func (c *Contractsv1) ListProductsPerContract(contractID, from, to string) (*Out
 // ListReportingGroups gets the IDs of the Content Provider (CP) reporting groups that you have access to along with their names.
 // To run this operation, your user account needs the CPCode Rep Group role.
 func (c *Contractsv1) ListReportingGroups() (*OutputReportingGroups, error) {
+	return c.ListReportingGroupsWithContext(context.Background())
+}
+
+// ListReportingGroupsWithContext is the same as ListReportingGroups with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (c *Contractsv1) ListReportingGroupsWithContext(ctx context.Context) (*OutputReportingGroups, error) {
+	ctx = client.WithOperation(ctx, "ListReportingGroups")
 	apiURI := fmt.Sprintf("%s/reportingGroups/", basePath)
 
 	// Create and execute request
 	resp, err := c.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputReportingGroups{}).
 		SetError(ContractsErrorv1{}).
 		Get(apiURI)
@@ -93,9 +115,7 @@ func (c *Contractsv1) ListReportingGroups() (*OutputReportingGroups, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*ContractsErrorv1)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputReportingGroups), nil
@@ -104,10 +124,18 @@ func (c *Contractsv1) ListReportingGroups() (*OutputReportingGroups, error) {
 // ListReportingGroupIDs gets the IDs of the Content Provider (CP) reporting groups that you have access to.
 // To run this operation, your user account needs the CPCode Rep Group role.
 func (c *Contractsv1) ListReportingGroupIDs() (*OutputReportingGroupIDs, error) {
+	return c.ListReportingGroupIDsWithContext(context.Background())
+}
+
+// ListReportingGroupIDsWithContext is the same as ListReportingGroupIDs with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (c *Contractsv1) ListReportingGroupIDsWithContext(ctx context.Context) (*OutputReportingGroupIDs, error) {
+	ctx = client.WithOperation(ctx, "ListReportingGroupIDs")
 	apiURI := fmt.Sprintf("%s/reportingGroups/identifiers", basePath)
 
 	// Create and execute request
 	resp, err := c.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputReportingGroupIDs{}).
 		SetError(ContractsErrorv1{}).
 		Get(apiURI)
@@ -117,9 +145,7 @@ func (c *Contractsv1) ListReportingGroupIDs() (*OutputReportingGroupIDs, error)
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*ContractsErrorv1)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputReportingGroupIDs), nil
@@ -140,26 +166,30 @@ func (c *Contractsv1) ListReportingGroupIDs() (*OutputReportingGroupIDs, error)
 // To - Ex: 2016-03-31. The end date, in UTC, to use when looking for products associated with a contract.
 //      The search always ends at 23:59:59 UTC of the specified date. The default end date is the current date.
 func (c *Contractsv1) ListProductsPerReportingGroup(reportingGroupID, from, to string) (*OutputProducts, *OutputContracts, error) {
-	query := map[string]string{}
+	return c.ListProductsPerReportingGroupWithContext(context.Background(), reportingGroupID, from, to)
+}
+
+// ListProductsPerReportingGroupWithContext is the same as ListProductsPerReportingGroup with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (c *Contractsv1) ListProductsPerReportingGroupWithContext(ctx context.Context, reportingGroupID, from, to string) (*OutputProducts, *OutputContracts, error) {
+	ctx = client.WithOperation(ctx, "ListProductsPerReportingGroup")
 	if reportingGroupID == "" {
 		return nil, nil, fmt.Errorf("Missing argument 'reportingGroupID'")
 	}
 
 	apiURI := fmt.Sprintf("%s/reportingGroups/%s/products/summaries", basePath, reportingGroupID)
 
-	if from != "" {
-		query["from"] = from
-	}
-
-	if to != "" {
-		query["to"] = to
+	query, err := client.QueryParams(productsOptions{From: from, To: to})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Create and execute request
 	resp, err := c.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputProducts{}).
 		SetError(ContractsErrorv1{}).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -167,9 +197,7 @@ func (c *Contractsv1) ListProductsPerReportingGroup(reportingGroupID, from, to s
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*ContractsErrorv1)
-
-		return nil, nil, e
+		return nil, nil, client.NewError(resp)
 	}
 
 	if resp.StatusCode() == 300 {
@@ -8,6 +8,13 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/contract-api/v1"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "contractsv1"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 10
+	defaultRateBurst = 10
 )
 
 // Contractsv1 provides the API operation methods for making requests to
@@ -19,20 +26,25 @@ type Contractsv1 struct {
 
 // New creates a new instance of the Contractsv1 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     // Create a Contractsv1 client from just a config.
-//     svc := Contractsv1.New(myConfig))
-func New(cfgs *edgegrid.Config) *Contractsv1 {
+//     svc, err := Contractsv1.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Contractsv1, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Contractsv1 {
-	svc := &Contractsv1{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Contractsv1, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Contractsv1{Client: c}, nil
 }
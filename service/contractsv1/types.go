@@ -61,3 +61,14 @@ type OutputReportingGroup struct {
 	// The descriptive name you supply for each reporting group.
 	Name string `json:"name"`
 }
+
+// listContractsOptions is query of ListContracts
+type listContractsOptions struct {
+	Depth ContractsDepth `url:"depth,omitempty"`
+}
+
+// productsOptions is query of ListProductsPerContract and ListProductsPerReportingGroup
+type productsOptions struct {
+	From string `url:"from,omitempty"`
+	To   string `url:"to,omitempty"`
+}
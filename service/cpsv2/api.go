@@ -1,6 +1,11 @@
 package cpsv2
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
 
 const (
 	enrollmentVersion = "application/vnd.akamai.cps.enrollments.v9+json"
@@ -8,20 +13,28 @@ const (
 
 // ListEnrollments retrieves all enrollments.
 func (cps *Cpsv2) ListEnrollments(contractID string) (*OutputEnrollments, error) {
-	query := map[string]string{}
+	return cps.ListEnrollmentsWithContext(context.Background(), contractID)
+}
 
-	if contractID != "" {
-		query["contractId"] = contractID
+// ListEnrollmentsWithContext is the same as ListEnrollments with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (cps *Cpsv2) ListEnrollmentsWithContext(ctx context.Context, contractID string) (*OutputEnrollments, error) {
+	ctx = client.WithOperation(ctx, "ListEnrollments")
+
+	query, err := client.QueryParams(listEnrollmentsOptions{ContractID: contractID})
+	if err != nil {
+		return nil, err
 	}
 
 	apiURI := fmt.Sprintf("%s/enrollments", basePath)
 
 	// Create and execute request
 	resp, err := cps.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputEnrollments{}).
 		SetError(CpsErrorv2{}).
 		SetHeader("Accept", enrollmentVersion).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -29,10 +42,58 @@ func (cps *Cpsv2) ListEnrollments(contractID string) (*OutputEnrollments, error)
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*CpsErrorv2)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputEnrollments), nil
 }
+
+// EnrollmentsPager iterates over enrollments, see ListEnrollmentsPager.
+type EnrollmentsPager struct {
+	*client.Pager
+}
+
+// Value returns current enrollment.
+func (p *EnrollmentsPager) Value() *OutputEnrollmentElement {
+	v, _ := p.Pager.Value().(*OutputEnrollmentElement)
+	return v
+}
+
+// ListEnrollmentsPager returns iterator over all enrollments.
+// Next pages are fetched on demand when API paginates results.
+func (cps *Cpsv2) ListEnrollmentsPager(ctx context.Context, contractID string) *EnrollmentsPager {
+	ctx = client.WithOperation(ctx, "ListEnrollments")
+
+	fetch := func(ctx context.Context, token *client.PageToken) ([]interface{}, *client.PageToken, error) {
+		query, err := client.QueryParams(listEnrollmentsOptions{ContractID: contractID})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req := cps.Client.Rclient.R().
+			SetContext(ctx).
+			SetResult(OutputEnrollments{}).
+			SetError(CpsErrorv2{}).
+			SetHeader("Accept", enrollmentVersion).
+			SetQueryParamsFromValues(query)
+
+		resp, err := client.GetPage(req, fmt.Sprintf("%s/enrollments", basePath), token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resp.IsError() {
+			return nil, nil, client.NewError(resp)
+		}
+
+		enrollments := resp.Result().(*OutputEnrollments).Enrollments
+		items := make([]interface{}, len(enrollments))
+		for i := range enrollments {
+			items[i] = &enrollments[i]
+		}
+
+		return items, client.NextPageToken(resp.Body(), token, len(items)), nil
+	}
+
+	return &EnrollmentsPager{client.NewPager(ctx, fetch)}
+}
@@ -8,6 +8,13 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/cps/v2"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "cpsv2"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 10
+	defaultRateBurst = 10
 )
 
 // Cpsv2 provides the API operation methods for making requests to
@@ -19,20 +26,25 @@ type Cpsv2 struct {
 
 // New creates a new instance of the Cpsv2 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     // Create a Cpsv2 client from just a config.
-//     svc := Cpsv2.New(myConfig))
-func New(cfgs *edgegrid.Config) *Cpsv2 {
+//     svc, err := Cpsv2.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Cpsv2, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Cpsv2 {
-	svc := &Cpsv2{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Cpsv2, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Cpsv2{Client: c}, nil
 }
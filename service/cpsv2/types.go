@@ -209,3 +209,8 @@ type OutputEnrollmentThirdParty struct {
 	// If this is true, then the SANs in the enrollment do not appear in the CSR that CPS submits to the CA.
 	ExcludeSans bool `json:"excludeSans"`
 }
+
+// listEnrollmentsOptions is query of ListEnrollments
+type listEnrollmentsOptions struct {
+	ContractID string `url:"contractId,omitempty"`
+}
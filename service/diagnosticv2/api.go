@@ -1,12 +1,14 @@
 package diagnosticv2
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+	"github.com/go-resty/resty/v2"
 )
 
 // IsStringInSlice returns TRUE is slice contains string and false if not
@@ -33,9 +35,17 @@ func executeFromSourceSupported(str string) bool {
 
 //ListGhostLocations returns location for ghost servers
 func (dts *Diagnosticv2) ListGhostLocations() (*GhostLocations, error) {
+	return dts.ListGhostLocationsWithContext(context.Background())
+}
+
+// ListGhostLocationsWithContext is the same as ListGhostLocations with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ListGhostLocationsWithContext(ctx context.Context) (*GhostLocations, error) {
+	ctx = client.WithOperation(ctx, "ListGhostLocations")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(GhostLocations{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/ghost-locations/available", basePath))
@@ -45,10 +55,7 @@ func (dts *Diagnosticv2) ListGhostLocations() (*GhostLocations, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*GhostLocations), nil
@@ -57,9 +64,17 @@ func (dts *Diagnosticv2) ListGhostLocations() (*GhostLocations, error) {
 
 // LaunchTranslateErrorAsync start async translation for given Akamai error code reference
 func (dts *Diagnosticv2) LaunchTranslateErrorAsync(errorCode string) (*TranslateErrorAsync, error) {
+	return dts.LaunchTranslateErrorAsyncWithContext(context.Background(), errorCode)
+}
+
+// LaunchTranslateErrorAsyncWithContext is the same as LaunchTranslateErrorAsync with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) LaunchTranslateErrorAsyncWithContext(ctx context.Context, errorCode string) (*TranslateErrorAsync, error) {
+	ctx = client.WithOperation(ctx, "LaunchTranslateErrorAsync")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(TranslateErrorAsync{}).
 		SetError(DiagnosticErrorv2{}).
 		Post(fmt.Sprintf("%s/errors/%s/translate-error", basePath, errorCode))
@@ -69,10 +84,7 @@ func (dts *Diagnosticv2) LaunchTranslateErrorAsync(errorCode string) (*Translate
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*TranslateErrorAsync), nil
@@ -80,9 +92,17 @@ func (dts *Diagnosticv2) LaunchTranslateErrorAsync(errorCode string) (*Translate
 
 // CheckTranslateErrorAsync polls for status of the StartTranslateErrorAsync returned request id
 func (dts *Diagnosticv2) CheckTranslateErrorAsync(requestID string) (*TranslateErrorAsync, error) {
+	return dts.CheckTranslateErrorAsyncWithContext(context.Background(), requestID)
+}
+
+// CheckTranslateErrorAsyncWithContext is the same as CheckTranslateErrorAsync with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) CheckTranslateErrorAsyncWithContext(ctx context.Context, requestID string) (*TranslateErrorAsync, error) {
+	ctx = client.WithOperation(ctx, "CheckTranslateErrorAsync")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(TranslateErrorAsync{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/translate-error-requests/%s", basePath, requestID))
@@ -92,10 +112,7 @@ func (dts *Diagnosticv2) CheckTranslateErrorAsync(requestID string) (*TranslateE
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*TranslateErrorAsync), nil
@@ -105,9 +122,17 @@ func (dts *Diagnosticv2) CheckTranslateErrorAsync(requestID string) (*TranslateE
 // RetrieveTranslateErrorAsync retrieves translated error message from Akamai platform
 // https://developer.akamai.com/api/core_features/diagnostic_tools/v2.html#gettranslateerrorperrequest
 func (dts *Diagnosticv2) RetrieveTranslateErrorAsync(requestID string) (*TranslatedError, error) {
+	return dts.RetrieveTranslateErrorAsyncWithContext(context.Background(), requestID)
+}
+
+// RetrieveTranslateErrorAsyncWithContext is the same as RetrieveTranslateErrorAsync with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) RetrieveTranslateErrorAsyncWithContext(ctx context.Context, requestID string) (*TranslatedError, error) {
+	ctx = client.WithOperation(ctx, "RetrieveTranslateErrorAsync")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(TranslatedError{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/translate-error-requests/%s/translated-error", basePath, requestID))
@@ -117,10 +142,7 @@ func (dts *Diagnosticv2) RetrieveTranslateErrorAsync(requestID string) (*Transla
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*TranslatedError), nil
@@ -129,40 +151,69 @@ func (dts *Diagnosticv2) RetrieveTranslateErrorAsync(requestID string) (*Transla
 
 // TranslateErrorAsync will make request and wait for response
 func (dts *Diagnosticv2) TranslateErrorAsync(errorCode string, retries int) (*TranslatedError, error) {
+	return dts.TranslateErrorAsyncWithContext(context.Background(), errorCode, retries)
+}
+
+// TranslateErrorAsyncWithContext is the same as TranslateErrorAsync with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) TranslateErrorAsyncWithContext(ctx context.Context, errorCode string, retries int) (*TranslatedError, error) {
+	ctx = client.WithOperation(ctx, "TranslateErrorAsync")
 	count := retries
-	// Create and execute request
-	resp, err := dts.Client.Rclient.R().
-		SetResult(TranslateErrorAsync{}).
-		SetError(DiagnosticErrorv2{}).
-		Post(fmt.Sprintf("%s/errors/%s/translate-error", basePath, errorCode))
 
+	launch := func() (*resty.Response, error) {
+		return dts.Client.Rclient.R().
+			SetContext(ctx).
+			SetResult(TranslateErrorAsync{}).
+			SetError(DiagnosticErrorv2{}).
+			Post(fmt.Sprintf("%s/errors/%s/translate-error", basePath, errorCode))
+	}
+
+	// Create and execute request
+	resp, err := launch()
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
+	// Request limit per 60 seconds reached, request is sent once more. Rate limiter
+	// holds it back until quota is renewed, without limiter Retry-After is respected
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		if dts.Client.RateLimiter == nil {
+			wait := translateRetryWait
+			if seconds, err := strconv.Atoi(resp.Header().Get("Retry-After")); err == nil && seconds >= 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+
+			dts.Client.Logger.Debugf("Request limit per 60 seconds reached. Will retry in %s", wait)
+			if err := client.SleepWithContext(ctx, wait); err != nil {
+				return nil, err
+			}
+		} else {
+			dts.Client.Logger.Debugf("Request limit per 60 seconds reached. Will retry once rate limiter allows")
+		}
+
+		if resp, err = launch(); err != nil {
+			return nil, err
 		}
 	}
 
+	if resp.IsError() {
+		return nil, client.NewError(resp)
+	}
+
 	req := resp.Result().(*TranslateErrorAsync)
 	requestID := req.RequestID
-	log.Debugf("Request for error code translation was submitted. Request ID is %s", requestID)
+	dts.Client.Logger.Debugf("Request for error code translation was submitted. Request ID is %s", requestID)
 
-	if resp.StatusCode() == http.StatusTooManyRequests {
-		log.Debugf("Request limit per 60 seconds reached. Will wait for a minute")
-		time.Sleep(61 * time.Second)
+	dts.Client.Logger.Debugf("Polling error code in %d seconds", req.RetryAfter)
+	if err := client.SleepWithContext(ctx, time.Duration(req.RetryAfter+1)*time.Second); err != nil {
+		return nil, err
 	}
 
-	log.Debugf("Polling error code in %d seconds", req.RetryAfter)
-	time.Sleep(time.Duration(req.RetryAfter+1) * time.Second)
-
 	// Check request
 	// With requestId and retryAfter data we can try to poll data
-	log.Debugf("Making Translate Error request for ID: %s. Attempt 1 out of %d", requestID, retries)
+	dts.Client.Logger.Debugf("Making Translate Error request for ID: %s. Attempt 1 out of %d", requestID, retries)
 	response, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(TranslatedError{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/translate-error-requests/%s/translated-error", basePath, requestID))
@@ -170,19 +221,22 @@ func (dts *Diagnosticv2) TranslateErrorAsync(errorCode string, retries int) (*Tr
 	count -= 2
 
 	if response.StatusCode() == http.StatusBadRequest {
-		return nil, response.Error().(*DiagnosticErrorv2)
+		return nil, client.NewError(response)
 	}
 
 	if err != nil || response.StatusCode() != http.StatusOK {
 		for {
-			log.Debugf("Polling error code in %d seconds", req.RetryAfter)
-			time.Sleep(time.Duration(req.RetryAfter+1) * time.Second)
+			dts.Client.Logger.Debugf("Polling error code in %d seconds", req.RetryAfter)
+			if err := client.SleepWithContext(ctx, time.Duration(req.RetryAfter+1)*time.Second); err != nil {
+				return nil, err
+			}
 
-			log.Debugf("Making Translate Error request for ID: %s. Attempt %d out of %d", requestID, retries-count, retries)
+			dts.Client.Logger.Debugf("Making Translate Error request for ID: %s. Attempt %d out of %d", requestID, retries-count, retries)
 
 			count--
 
 			response, err = dts.Client.Rclient.R().
+				SetContext(ctx).
 				SetResult(TranslatedError{}).
 				SetError(DiagnosticErrorv2{}).
 				Get(fmt.Sprintf("%s/translate-error-requests/%s/translated-error", basePath, requestID))
@@ -192,11 +246,11 @@ func (dts *Diagnosticv2) TranslateErrorAsync(errorCode string, retries int) (*Tr
 			}
 
 			if response.StatusCode() == http.StatusBadRequest {
-				return nil, response.Error().(*DiagnosticErrorv2)
+				return nil, client.NewError(response)
 			}
 
 			if response.StatusCode() == http.StatusForbidden {
-				return nil, response.Error().(*DiagnosticErrorv2)
+				return nil, client.NewError(response)
 			}
 
 			if response.StatusCode() == http.StatusOK {
@@ -214,9 +268,17 @@ func (dts *Diagnosticv2) TranslateErrorAsync(errorCode string, retries int) (*Tr
 
 // CheckIPAddress checks if given IP belongs to Akamai CDN
 func (dts *Diagnosticv2) CheckIPAddress(ip string) (*CDNStatus, error) {
+	return dts.CheckIPAddressWithContext(context.Background(), ip)
+}
+
+// CheckIPAddressWithContext is the same as CheckIPAddress with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) CheckIPAddressWithContext(ctx context.Context, ip string) (*CDNStatus, error) {
+	ctx = client.WithOperation(ctx, "CheckIPAddress")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(CDNStatus{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/ip-addresses/%s/is-cdn-ip", basePath, ip))
@@ -226,10 +288,7 @@ func (dts *Diagnosticv2) CheckIPAddress(ip string) (*CDNStatus, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*CDNStatus), nil
@@ -237,6 +296,13 @@ func (dts *Diagnosticv2) CheckIPAddress(ip string) (*CDNStatus, error) {
 
 // CreateDiagnosticLink generates user link and request
 func (dts *Diagnosticv2) GenerateDiagnosticLink(username, testURL string) (*DiagnosticLinkURL, error) {
+	return dts.GenerateDiagnosticLinkWithContext(context.Background(), username, testURL)
+}
+
+// GenerateDiagnosticLinkWithContext is the same as GenerateDiagnosticLink with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) GenerateDiagnosticLinkWithContext(ctx context.Context, username, testURL string) (*DiagnosticLinkURL, error) {
+	ctx = client.WithOperation(ctx, "GenerateDiagnosticLink")
 
 	diagnosticLinkRequest := DiagnosticLinkRequest{
 		EndUserName: username,
@@ -245,6 +311,7 @@ func (dts *Diagnosticv2) GenerateDiagnosticLink(username, testURL string) (*Diag
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetBody(diagnosticLinkRequest).
 		SetResult(DiagnosticLinkURL{}).
 		SetError(DiagnosticErrorv2{}).
@@ -255,10 +322,7 @@ func (dts *Diagnosticv2) GenerateDiagnosticLink(username, testURL string) (*Diag
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*DiagnosticLinkURL), nil
@@ -266,8 +330,16 @@ func (dts *Diagnosticv2) GenerateDiagnosticLink(username, testURL string) (*Diag
 
 // ListDiagnosticLinkRequests lists all requests
 func (dts *Diagnosticv2) ListDiagnosticLinkRequests() (*DiagnosticLinkRequests, error) {
+	return dts.ListDiagnosticLinkRequestsWithContext(context.Background())
+}
+
+// ListDiagnosticLinkRequestsWithContext is the same as ListDiagnosticLinkRequests with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ListDiagnosticLinkRequestsWithContext(ctx context.Context) (*DiagnosticLinkRequests, error) {
+	ctx = client.WithOperation(ctx, "ListDiagnosticLinkRequests")
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(DiagnosticLinkRequests{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/end-users/ip-requests", basePath))
@@ -277,20 +349,67 @@ func (dts *Diagnosticv2) ListDiagnosticLinkRequests() (*DiagnosticLinkRequests,
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*DiagnosticLinkRequests), nil
 }
 
+// DiagnosticLinkRequestsPager iterates over diagnostic link requests, see ListDiagnosticLinkRequestsPager.
+type DiagnosticLinkRequestsPager struct {
+	*client.Pager
+}
+
+// Value returns current diagnostic link request.
+func (p *DiagnosticLinkRequestsPager) Value() *DiagnosticLinkRequestItem {
+	v, _ := p.Pager.Value().(*DiagnosticLinkRequestItem)
+	return v
+}
+
+// ListDiagnosticLinkRequestsPager returns iterator over all requests.
+// Next pages are fetched on demand when API paginates results.
+func (dts *Diagnosticv2) ListDiagnosticLinkRequestsPager(ctx context.Context) *DiagnosticLinkRequestsPager {
+	ctx = client.WithOperation(ctx, "ListDiagnosticLinkRequests")
+	fetch := func(ctx context.Context, token *client.PageToken) ([]interface{}, *client.PageToken, error) {
+		req := dts.Client.Rclient.R().
+			SetContext(ctx).
+			SetResult(DiagnosticLinkRequests{}).
+			SetError(DiagnosticErrorv2{})
+
+		resp, err := client.GetPage(req, fmt.Sprintf("%s/end-users/ip-requests", basePath), token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resp.IsError() {
+			return nil, nil, client.NewError(resp)
+		}
+
+		requests := resp.Result().(*DiagnosticLinkRequests).EndUserIPRequests
+		items := make([]interface{}, len(requests))
+		for i := range requests {
+			items[i] = &requests[i]
+		}
+
+		return items, client.NextPageToken(resp.Body(), token, len(items)), nil
+	}
+
+	return &DiagnosticLinkRequestsPager{client.NewPager(ctx, fetch)}
+}
+
 // RetrieveDiagnosticLinkRequest gets request details
 func (dts *Diagnosticv2) RetrieveDiagnosticLinkRequest(id string) (*DiagnosticLinkResult, error) {
+	return dts.RetrieveDiagnosticLinkRequestWithContext(context.Background(), id)
+}
+
+// RetrieveDiagnosticLinkRequestWithContext is the same as RetrieveDiagnosticLinkRequest with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) RetrieveDiagnosticLinkRequestWithContext(ctx context.Context, id string) (*DiagnosticLinkResult, error) {
+	ctx = client.WithOperation(ctx, "RetrieveDiagnosticLinkRequest")
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(DiagnosticLinkResult{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/end-users/ip-requests/%s/ip-details", basePath, id))
@@ -300,10 +419,7 @@ func (dts *Diagnosticv2) RetrieveDiagnosticLinkRequest(id string) (*DiagnosticLi
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*DiagnosticLinkResult), nil
@@ -311,8 +427,16 @@ func (dts *Diagnosticv2) RetrieveDiagnosticLinkRequest(id string) (*DiagnosticLi
 
 // RetrieveIPGeolocation provides given IP geolocation details
 func (dts *Diagnosticv2) RetrieveIPGeolocation(ip string) (*Geolocation, error) {
+	return dts.RetrieveIPGeolocationWithContext(context.Background(), ip)
+}
+
+// RetrieveIPGeolocationWithContext is the same as RetrieveIPGeolocation with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) RetrieveIPGeolocationWithContext(ctx context.Context, ip string) (*Geolocation, error) {
+	ctx = client.WithOperation(ctx, "RetrieveIPGeolocation")
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(Geolocation{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/ip-addresses/%s/geo-location", basePath, ip))
@@ -322,10 +446,7 @@ func (dts *Diagnosticv2) RetrieveIPGeolocation(ip string) (*Geolocation, error)
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*Geolocation), nil
@@ -333,16 +454,26 @@ func (dts *Diagnosticv2) RetrieveIPGeolocation(ip string) (*Geolocation, error)
 
 // ExecuteDig against a hostname to get DNS information, associating hostnames and IP addresses, from an IP address within the Akamai network not local to you. Specify the hostName as a query parameter, and an optional DNS queryType. See the Dig object for details on the response data.
 func (dts *Diagnosticv2) ExecuteDig(obj, requestFrom, hostname, query string) (*DigResult, error) {
+	return dts.ExecuteDigWithContext(context.Background(), obj, requestFrom, hostname, query)
+}
+
+// ExecuteDigWithContext is the same as ExecuteDig with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ExecuteDigWithContext(ctx context.Context, obj, requestFrom, hostname, query string) (*DigResult, error) {
+	ctx = client.WithOperation(ctx, "ExecuteDig")
 	if !executeFromSourceSupported(requestFrom) {
 		return nil, fmt.Errorf("requestFrom value should be one of ['ghost-locations', 'ip-addresses'], you provided %s", requestFrom)
 	}
 
+	params, err := client.QueryParams(digOptions{HostName: hostname, QueryType: query})
+	if err != nil {
+		return nil, err
+	}
+
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
-		SetQueryParams(map[string]string{
-			"hostName":  hostname,
-			"queryType": query,
-		}).
+		SetContext(ctx).
+		SetQueryParamsFromValues(params).
 		SetResult(DigResult{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/%s/%s/dig-info", basePath, requestFrom, obj))
@@ -355,10 +486,7 @@ func (dts *Diagnosticv2) ExecuteDig(obj, requestFrom, hostname, query string) (*
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*DigResult), nil
@@ -366,16 +494,26 @@ func (dts *Diagnosticv2) ExecuteDig(obj, requestFrom, hostname, query string) (*
 
 // ExecuteMtr provides mtr functionality
 func (dts *Diagnosticv2) ExecuteMtr(obj, requestFrom, destinationDomain string, resolveDNS bool) (*MtrResult, error) {
+	return dts.ExecuteMtrWithContext(context.Background(), obj, requestFrom, destinationDomain, resolveDNS)
+}
+
+// ExecuteMtrWithContext is the same as ExecuteMtr with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ExecuteMtrWithContext(ctx context.Context, obj, requestFrom, destinationDomain string, resolveDNS bool) (*MtrResult, error) {
+	ctx = client.WithOperation(ctx, "ExecuteMtr")
 	if !executeFromSourceSupported(requestFrom) {
 		return nil, fmt.Errorf("requestFrom value should be one of ['ghost-locations', 'ip-addresses'], you provided %s", requestFrom)
 	}
 
+	query, err := client.QueryParams(mtrOptions{ResolveDNS: resolveDNS, DestinationDomain: destinationDomain})
+	if err != nil {
+		return nil, err
+	}
+
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
-		SetQueryParams(map[string]string{
-			"resolveDns":        strconv.FormatBool(resolveDNS),
-			"destinationDomain": destinationDomain,
-		}).
+		SetContext(ctx).
+		SetQueryParamsFromValues(query).
 		SetResult(MtrResult{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/%s/%s/mtr-data", basePath, requestFrom, obj))
@@ -385,10 +523,7 @@ func (dts *Diagnosticv2) ExecuteMtr(obj, requestFrom, destinationDomain string,
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*MtrResult), nil
@@ -396,6 +531,13 @@ func (dts *Diagnosticv2) ExecuteMtr(obj, requestFrom, destinationDomain string,
 
 // ExecuteCurl provides curl functionality
 func (dts *Diagnosticv2) ExecuteCurl(obj, requestFrom, testURL, userAgent string) (*CurlResult, error) {
+	return dts.ExecuteCurlWithContext(context.Background(), obj, requestFrom, testURL, userAgent)
+}
+
+// ExecuteCurlWithContext is the same as ExecuteCurl with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ExecuteCurlWithContext(ctx context.Context, obj, requestFrom, testURL, userAgent string) (*CurlResult, error) {
+	ctx = client.WithOperation(ctx, "ExecuteCurl")
 	if !executeFromSourceSupported(requestFrom) {
 		return nil, fmt.Errorf("requestFrom value should be one of ['ghost-locations', 'ip-addresses'], you provided %s", requestFrom)
 	}
@@ -407,6 +549,7 @@ func (dts *Diagnosticv2) ExecuteCurl(obj, requestFrom, testURL, userAgent string
 
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetBody(curlRequest).
 		SetResult(CurlResult{}).
 		SetError(DiagnosticErrorv2{}).
@@ -417,10 +560,7 @@ func (dts *Diagnosticv2) ExecuteCurl(obj, requestFrom, testURL, userAgent string
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*CurlResult), nil
@@ -428,8 +568,16 @@ func (dts *Diagnosticv2) ExecuteCurl(obj, requestFrom, testURL, userAgent string
 
 // ListGTMProperties provides available GTM properties
 func (dts *Diagnosticv2) ListGTMProperties() (*GTMPropertiesResult, error) {
+	return dts.ListGTMPropertiesWithContext(context.Background())
+}
+
+// ListGTMPropertiesWithContext is the same as ListGTMProperties with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ListGTMPropertiesWithContext(ctx context.Context) (*GTMPropertiesResult, error) {
+	ctx = client.WithOperation(ctx, "ListGTMProperties")
 	// Create and execute request
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(GTMPropertiesResult{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/gtm/gtm-properties", basePath))
@@ -439,10 +587,7 @@ func (dts *Diagnosticv2) ListGTMProperties() (*GTMPropertiesResult, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*GTMPropertiesResult), nil
@@ -450,6 +595,13 @@ func (dts *Diagnosticv2) ListGTMProperties() (*GTMPropertiesResult, error) {
 
 // ListGTMPropertyIPs provides available GTM properties
 func (dts *Diagnosticv2) ListGTMPropertyIPs(property, domain string) (*GTMPropertyIpsResult, error) {
+	return dts.ListGTMPropertyIPsWithContext(context.Background(), property, domain)
+}
+
+// ListGTMPropertyIPsWithContext is the same as ListGTMPropertyIPs with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (dts *Diagnosticv2) ListGTMPropertyIPsWithContext(ctx context.Context, property, domain string) (*GTMPropertyIpsResult, error) {
+	ctx = client.WithOperation(ctx, "ListGTMPropertyIPs")
 
 	if property == "" {
 		return nil, fmt.Errorf("'property' is required parameter: '%s'", property)
@@ -460,6 +612,7 @@ func (dts *Diagnosticv2) ListGTMPropertyIPs(property, domain string) (*GTMProper
 	}
 
 	resp, err := dts.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(GTMPropertyIpsResult{}).
 		SetError(DiagnosticErrorv2{}).
 		Get(fmt.Sprintf("%s/gtm/%s/%s/gtm-property-ips", basePath, property, domain))
@@ -469,10 +622,7 @@ func (dts *Diagnosticv2) ListGTMPropertyIPs(property, domain string) (*GTMProper
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*DiagnosticErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*GTMPropertyIpsResult), nil
@@ -0,0 +1,69 @@
+package diagnosticv2
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupEdgeClient prepares and inits client for making all calls towards Akamai's APIs
+func setupEdgeClient(baseURL string) *Diagnosticv2 {
+	creds, err := edgegrid.NewCredentials().FromJSON(`{ "client_secret": "kljwekfjf", "host": "akab-k2112.31k23jl1k23.luna.akamaiapis.net", "access_token": "akab-l12h3iu123y923huk-4uc54n5xmwhqu4zh", "client_token": "akab-90821u3hkjbnmk-jkhg" }`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	cfg := edgegrid.NewConfig().
+		WithCredentials(creds).
+		WithLogVerbosity("info").
+		WithLocalTesting(true).
+		WithScheme("http").
+		WithTestingURL(baseURL).
+		WithRateLimiter(serviceName, client.NewTokenBucket(100, 10))
+
+	client, err := New(cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return client
+}
+
+func TestTranslateErrorAsyncRetriesRateLimitedRequest(t *testing.T) {
+	var launches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case basePath + "/errors/9.6f64d440.1318965461.2f2b078/translate-error":
+			launches++
+			if launches == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"status":429,"title":"Too Many Requests"}`)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"requestId":"abc-123","link":"/diagnostic-tools/v2/translate-error-requests/abc-123","retryAfter":0}`)
+		case basePath + "/translate-error-requests/abc-123/translated-error":
+			fmt.Fprint(w, `{"translatedError":{"url":"https://www.example.com/","httpResponseCode":504}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":404,"title":"Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	translated, err := apiClient.TranslateErrorAsync("9.6f64d440.1318965461.2f2b078", 3)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, launches, "Rate limited request should be sent once more")
+		assert.Equal(t, 504, translated.TranslatedError.HTTPResponseCode)
+	}
+}
@@ -1,6 +1,8 @@
 package diagnosticv2
 
 import (
+	"time"
+
 	"github.com/apiheat/go-edgegrid/v6/edgegrid"
 	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
@@ -8,6 +10,16 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/diagnostic-tools/v2"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "diagnosticv2"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 100.0 / 60
+	defaultRateBurst = 10
+
+	// Represents wait before error translation is requested again after request limit was reached.
+	translateRetryWait = 61 * time.Second
 )
 
 // Diagnosticv2 provides the API operation methods for making requests to
@@ -19,20 +31,25 @@ type Diagnosticv2 struct {
 
 // New creates a new instance of the Diagnosticv2 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     // Create a Diagnosticv2 client from just a config.
-//     svc := diagnosticv2.New(myConfig))
-func New(cfgs *edgegrid.Config) *Diagnosticv2 {
+//     svc, err := diagnosticv2.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Diagnosticv2, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Diagnosticv2 {
-	svc := &Diagnosticv2{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Diagnosticv2, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Diagnosticv2{Client: c}, nil
 }
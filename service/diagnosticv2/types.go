@@ -102,12 +102,15 @@ type DiagnosticLinkRequest struct {
 }
 
 type DiagnosticLinkRequests struct {
-	EndUserIPRequests []struct {
-		EndUserName string    `json:"name"`
-		RequestID   uint32    `json:"requestId"`
-		URL         string    `json:"url"`
-		Timestamp   time.Time `json:"timestamp"`
-	} `json:"endUserIpRequests"`
+	EndUserIPRequests []DiagnosticLinkRequestItem `json:"endUserIpRequests"`
+}
+
+// DiagnosticLinkRequestItem represents single request listed by ListDiagnosticLinkRequests
+type DiagnosticLinkRequestItem struct {
+	EndUserName string    `json:"name"`
+	RequestID   uint32    `json:"requestId"`
+	URL         string    `json:"url"`
+	Timestamp   time.Time `json:"timestamp"`
 }
 
 type DiagnosticLinkResult struct {
@@ -236,3 +239,15 @@ type GTMPropertyIpsResult struct {
 		TargetIps []string `json:"targetIps"`
 	} `json:"gtmPropertyIps"`
 }
+
+// digOptions is query of ExecuteDig
+type digOptions struct {
+	HostName  string `url:"hostName"`
+	QueryType string `url:"queryType"`
+}
+
+// mtrOptions is query of ExecuteMtr
+type mtrOptions struct {
+	ResolveDNS        bool   `url:"resolveDns"`
+	DestinationDomain string `url:"destinationDomain"`
+}
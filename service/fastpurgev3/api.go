@@ -1,47 +1,142 @@
 package fastpurgev3
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // PurgeCacheByURL Invalidates content on the selected URL for the selected network.
+// Objects are absolute URLs, ARLs or, when hostname is set, paths.
 // Akamai API docs: https://developer.akamai.com/api/core_features/fast_purge/v3.html
 func (fp *Fastpurgev3) PurgeCacheByURL(opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	return fp.PurgeCacheByURLWithContext(context.Background(), opts, tier, purgeStrategy)
+}
 
-	resp, err := fp.executePurgeRequest(opts, purgeStrategy, tier, URL)
-
-	return resp, err
+// PurgeCacheByURLWithContext is the same as PurgeCacheByURL with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (fp *Fastpurgev3) PurgeCacheByURLWithContext(ctx context.Context, opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	ctx = client.WithOperation(ctx, "PurgeCacheByURL")
+
+	// Objects used to be passed as is, so ARLs are still accepted among URLs
+	req := NewURLPurge().WithHostname(opts.Hostname)
+	for _, object := range opts.Objects {
+		if opts.Hostname == "" && arlPattern.MatchString(object) {
+			req.WithARLs(object)
+		} else {
+			req.WithURLs(object)
+		}
+	}
 
+	return fp.executeSinglePurge(ctx, req, tier, purgeStrategy)
 }
 
 // PurgeCacheByCPCode Invalidates content on the selected CPCODE for the selected network.
+// Objects have to be numeric CP codes.
 // Akamai API docs: https://developer.akamai.com/api/core_features/fast_purge/v3.html
 func (fp *Fastpurgev3) PurgeCacheByCPCode(opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	return fp.PurgeCacheByCPCodeWithContext(context.Background(), opts, tier, purgeStrategy)
+}
 
-	resp, err := fp.executePurgeRequest(opts, purgeStrategy, tier, URL)
-
-	return resp, err
+// PurgeCacheByCPCodeWithContext is the same as PurgeCacheByCPCode with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (fp *Fastpurgev3) PurgeCacheByCPCodeWithContext(ctx context.Context, opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	ctx = client.WithOperation(ctx, "PurgeCacheByCPCode")
+
+	req := NewCPCodePurge()
+	for _, object := range opts.Objects {
+		code, err := strconv.Atoi(object)
+		if err != nil {
+			return nil, errInvalidObject("Invalid CP code", object)
+		}
+		req.WithCPCodes(code)
+	}
 
+	return fp.executeSinglePurge(ctx, req, tier, purgeStrategy)
 }
 
-// PurgeCacheByCacheTag Invalidates content on the selected CPCODE for the selected network.
+// PurgeCacheByCacheTag Invalidates content labeled with the selected cache tags for the selected network.
 // Akamai API docs: https://developer.akamai.com/api/core_features/fast_purge/v3.html
 func (fp *Fastpurgev3) PurgeCacheByCacheTag(opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	return fp.PurgeCacheByCacheTagWithContext(context.Background(), opts, tier, purgeStrategy)
+}
+
+// PurgeCacheByCacheTagWithContext is the same as PurgeCacheByCacheTag with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (fp *Fastpurgev3) PurgeCacheByCacheTagWithContext(ctx context.Context, opts FastPurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	ctx = client.WithOperation(ctx, "PurgeCacheByCacheTag")
+
+	return fp.executeSinglePurge(ctx, NewCacheTagPurge(opts.Objects...), tier, purgeStrategy)
+}
+
+// Purge validates request built with NewURLPurge, NewCPCodePurge or NewCacheTagPurge
+// and sends it to the endpoint of its purge type. Requests exceeding body size limit
+// are split into batches and results of all batches are returned. When one of the
+// batches fails, results of batches sent so far are returned together with the error.
+// Akamai API docs: https://developer.akamai.com/api/core_features/fast_purge/v3.html
+func (fp *Fastpurgev3) Purge(req PurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResults, error) {
+	return fp.PurgeWithContext(context.Background(), req, tier, purgeStrategy)
+}
+
+// PurgeWithContext is the same as Purge with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (fp *Fastpurgev3) PurgeWithContext(ctx context.Context, req PurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResults, error) {
+	ctx = client.WithOperation(ctx, "Purge")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	batches, err := req.Batches(MaxRequestBodySize)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := fp.executePurgeRequest(opts, purgeStrategy, tier, URL)
+	results := &FastPurgeResults{}
+	for _, batch := range batches {
+		result, err := fp.executePurgeRequest(ctx, batch, purgeStrategy, tier, batch.PurgeType())
+		if err != nil {
+			return results, err
+		}
+
+		results.add(*result, batch.Objects())
+	}
+
+	return results, nil
+}
 
-	return resp, err
+// executeSinglePurge validates request and sends it when it fits into single purge request
+func (fp *Fastpurgev3) executeSinglePurge(ctx context.Context, req PurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) (*FastPurgeResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	batches, err := req.Batches(MaxRequestBodySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batches) > 1 {
+		return nil, ErrorPurgeRequest{
+			ErrorMessage: "Purge request exceeds request body size limit, use Purge to send it in batches",
+			ErrorType:    "ErrorPurgeRequestTooLarge",
+		}
+	}
 
+	return fp.executePurgeRequest(ctx, req, purgeStrategy, tier, req.PurgeType())
 }
 
 //executePurgeRequest executes request to invalidate cache based on given conditions.
 //AkamaiPurgeStrategy: delete | invalidate
 //AkamaiEnvironment: production | staging
 //AkamaiPurgeType: URL|cpcode|cache tag
-func (fp *Fastpurgev3) executePurgeRequest(opts FastPurgeRequest, purgeStrategy AkamaiPurgeStrategy, tier AkamaiEnvironment, purgeType AkamaiPurgeType) (*FastPurgeResult, error) {
+func (fp *Fastpurgev3) executePurgeRequest(ctx context.Context, opts PurgeRequest, purgeStrategy AkamaiPurgeStrategy, tier AkamaiEnvironment, purgeType AkamaiPurgeType) (*FastPurgeResult, error) {
 
 	// Create and execute request
 	resp, err := fp.Client.Rclient.R().
+		SetContext(ctx).
 		SetBody(opts).
 		SetResult(FastPurgeResult{}).
 		SetError(FastpurgeError{}).
@@ -52,8 +147,7 @@ func (fp *Fastpurgev3) executePurgeRequest(opts FastPurgeRequest, purgeStrategy
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*FastpurgeError)
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*FastPurgeResult), nil
@@ -18,3 +18,22 @@ func (b FastpurgeError) Error() string {
 
 	return msg
 }
+
+// ErrorPurgeRequest represents purge request rejected before it was sent
+// i.e. because of invalid object or body exceeding size limit.
+type ErrorPurgeRequest struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorType    string `json:"error_type"`
+
+	// Object is the purge object which caused the error, if any
+	Object string `json:"object,omitempty"`
+}
+
+// ErrorPurgeRequest implements the error interface.
+func (e ErrorPurgeRequest) Error() string {
+	if e.Object != "" {
+		return fmt.Sprintf("%s: %s", e.ErrorMessage, e.Object)
+	}
+
+	return e.ErrorMessage
+}
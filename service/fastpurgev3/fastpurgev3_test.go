@@ -0,0 +1,441 @@
+package fastpurgev3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupEdgeClient prepares and inits client for making all calls towards Akamai's APIs
+func setupEdgeClient() *Fastpurgev3 {
+	// Get credentials
+	creds, err := edgegrid.NewCredentials().FromJSON(`{ "client_secret": "kljwekfjf", "host": "akab-k2112.31k23jl1k23.luna.akamaiapis.net", "access_token": "akab-l12h3iu123y923huk-4uc54n5xmwhqu4zh", "client_token": "akab-90821u3hkjbnmk-jkhg" }`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Create configuration and specify some of the configuration items
+	cfg := edgegrid.NewConfig().
+		WithCredentials(creds).
+		WithLocalTesting(true).
+		WithScheme("http").
+		WithTestingURL("http://test.local")
+
+	// Create new client
+	client, err := New(cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return client
+}
+
+// purgeResponder records bodies of purge requests and replies with new purge ID
+func purgeResponder(t *testing.T, bodies *[]string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(body), MaxRequestBodySize, "Request body should be within limit")
+
+		*bodies = append(*bodies, string(body))
+
+		return httpmock.NewJsonResponse(201, FastPurgeResult{
+			HTTPStatus:       201,
+			EstimatedSeconds: int64(5 * len(*bodies)),
+			PurgeID:          fmt.Sprintf("purge-%d", len(*bodies)),
+			Detail:           "Request accepted",
+		})
+	}
+}
+
+func TestPurgeRoutesByType(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var cpcodes, tags []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/cpcode/staging", purgeResponder(t, &cpcodes))
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/delete/tag/production", purgeResponder(t, &tags))
+
+	_, err := apiClient.PurgeCacheByCPCode(FastPurgeRequest{Objects: []string{"12345"}}, Staging, Invalidate)
+	if assert.NoError(t, err) && assert.Len(t, cpcodes, 1) {
+		assert.JSONEq(t, `{"objects":[12345]}`, cpcodes[0])
+	}
+
+	_, err = apiClient.PurgeCacheByCacheTag(FastPurgeRequest{Objects: []string{"products"}}, Production, Delete)
+	if assert.NoError(t, err) && assert.Len(t, tags, 1) {
+		assert.JSONEq(t, `{"objects":["products"]}`, tags[0])
+	}
+
+	_, err = apiClient.PurgeCacheByCPCode(FastPurgeRequest{Objects: []string{"abc"}}, Staging, Invalidate)
+	var reqErr ErrorPurgeRequest
+	if assert.True(t, errors.As(err, &reqErr)) {
+		assert.Equal(t, "ErrorPurgeRequestObject", reqErr.ErrorType)
+		assert.Equal(t, "abc", reqErr.Object)
+	}
+}
+
+func TestPurgeCacheByURLAcceptsARLs(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var urls []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/url/production", purgeResponder(t, &urls))
+
+	_, err := apiClient.PurgeCacheByURL(FastPurgeRequest{Objects: []string{
+		"https://www.example.com/index.html",
+		"/L/1234/5678/1d/www.example.com/index.html",
+	}}, Production, Invalidate)
+	if assert.NoError(t, err) && assert.Len(t, urls, 1) {
+		assert.JSONEq(t, `{"objects":["https://www.example.com/index.html","/L/1234/5678/1d/www.example.com/index.html"]}`, urls[0])
+	}
+}
+
+func TestPurgeValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PurgeRequest
+		err  string
+	}{
+		{"empty", NewURLPurge(), "ErrorPurgeRequestEmpty"},
+		{"url", NewURLPurge().WithURLs("https://www.example.com/index.html"), ""},
+		{"relative url", NewURLPurge().WithURLs("/index.html"), "ErrorPurgeRequestObject"},
+		{"path", NewURLPurge().WithHostname("www.example.com").WithURLs("/index.html"), ""},
+		{"url with hostname", NewURLPurge().WithHostname("www.example.com").WithURLs("https://www.example.com/"), "ErrorPurgeRequestObject"},
+		{"arl", NewURLPurge().WithARLs("/L/1234/5678/1d/www.example.com/index.html"), ""},
+		{"arl with hostname", NewURLPurge().WithHostname("www.example.com").WithARLs("/L/1234/5678/1d/www.example.com/index.html"), "ErrorPurgeRequestObject"},
+		{"cpcode", NewCPCodePurge(12345), ""},
+		{"negative cpcode", NewCPCodePurge(-1), "ErrorPurgeRequestObject"},
+		{"tag", NewCacheTagPurge("product-1", "price_list"), ""},
+		{"tag with space", NewCacheTagPurge("product 1"), "ErrorPurgeRequestObject"},
+		{"long tag", NewCacheTagPurge(strings.Repeat("a", MaxCacheTagLength+1)), "ErrorPurgeRequestObject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var reqErr ErrorPurgeRequest
+			if assert.True(t, errors.As(err, &reqErr)) {
+				assert.Equal(t, tt.err, reqErr.ErrorType)
+			}
+		})
+	}
+}
+
+func TestPurgeBatches(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var bodies []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/url/production", purgeResponder(t, &bodies))
+
+	urls := make([]string, 2000)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://www.example.com/assets/images/product-%04d.jpg", i)
+	}
+
+	req := NewURLPurge().WithURLs(urls...)
+
+	_, err := apiClient.PurgeCacheByURL(FastPurgeRequest{Objects: urls}, Production, Invalidate)
+	var reqErr ErrorPurgeRequest
+	if assert.True(t, errors.As(err, &reqErr), "Single purge should reject too large body") {
+		assert.Equal(t, "ErrorPurgeRequestTooLarge", reqErr.ErrorType)
+	}
+
+	results, err := apiClient.Purge(req, Production, Invalidate)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, bodies, 3, "Objects should be split into 3 batches")
+	assert.Equal(t, []string{"purge-1", "purge-2", "purge-3"}, results.PurgeIDs)
+	assert.Equal(t, int64(15), results.EstimatedSeconds)
+
+	var sent []string
+	for _, body := range bodies {
+		var batch FastPurgeRequest
+		assert.NoError(t, json.Unmarshal([]byte(body), &batch))
+		sent = append(sent, batch.Objects...)
+	}
+	assert.Equal(t, urls, sent, "All objects should be sent once and in order")
+}
+
+func TestBatchRangesExactLimit(t *testing.T) {
+	// {"objects":[]} is 14 bytes, every tag "aa" takes 4 bytes and comma 1
+	req := NewCacheTagPurge("aa", "aa", "aa", "aa")
+
+	batches, err := req.Batches(14 + 4 + 1 + 4)
+	if assert.NoError(t, err) && assert.Len(t, batches, 2) {
+		body, _ := json.Marshal(batches[0])
+		assert.Len(t, body, 23)
+	}
+
+	_, err = req.Batches(17)
+	var reqErr ErrorPurgeRequest
+	if assert.True(t, errors.As(err, &reqErr)) {
+		assert.Equal(t, "ErrorPurgeRequestTooLarge", reqErr.ErrorType)
+	}
+}
+
+func TestWaitForPurgeVerifiesURLs(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	staleHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		age := "0"
+		switch r.URL.Path {
+		case "/stale":
+			if staleHits++; staleHits < 3 {
+				age = "3600"
+			}
+		case "/never":
+			age = "3600"
+		}
+		w.Header().Set("Age", age)
+	}))
+	defer server.Close()
+
+	results := &FastPurgeResults{PurgeIDs: []string{"purge-1"}, SubmittedAt: time.Now()}
+
+	report, err := apiClient.WaitForPurge(results, PurgeWaitOptions{
+		VerifyURLs: []string{server.URL + "/fresh", server.URL + "/stale"},
+		Interval:   time.Millisecond,
+	})
+	if assert.NoError(t, err) {
+		assert.True(t, report.Completed())
+		assert.Equal(t, 1, report.Objects[0].Attempts)
+		assert.Equal(t, 3, report.Objects[1].Attempts)
+	}
+
+	report, err = apiClient.WaitForPurge(results, PurgeWaitOptions{
+		VerifyURLs:  []string{server.URL + "/fresh", server.URL + "/never"},
+		Interval:    time.Millisecond,
+		MaxAttempts: 2,
+	})
+	var reqErr ErrorPurgeRequest
+	if assert.True(t, errors.As(err, &reqErr)) {
+		assert.Equal(t, "ErrorPurgeNotVerified", reqErr.ErrorType)
+		assert.Equal(t, []string{server.URL + "/never"}, report.Pending())
+	}
+}
+
+func TestWaitForPurgeEstimate(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	results := &FastPurgeResults{
+		PurgeIDs:         []string{"purge-1"},
+		Objects:          []string{"12345"},
+		EstimatedSeconds: 1,
+		SubmittedAt:      time.Now().Add(-900 * time.Millisecond),
+	}
+
+	report, err := apiClient.WaitForPurge(results, PurgeWaitOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, report.Completed())
+		assert.Equal(t, "12345", report.Objects[0].Object)
+		assert.True(t, report.Waited >= 50*time.Millisecond, "Should wait for rest of the estimate")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results.SubmittedAt = time.Now()
+	report, err = apiClient.WaitForPurgeWithContext(ctx, results, PurgeWaitOptions{})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"12345"}, report.Pending())
+}
+
+func TestWaitForPurgeResult(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	_, err := apiClient.WaitForPurge(nil, PurgeWaitOptions{})
+	var reqErr ErrorPurgeRequest
+	if assert.True(t, errors.As(err, &reqErr)) {
+		assert.Equal(t, "ErrorPurgeRequestEmpty", reqErr.ErrorType)
+	}
+
+	results := NewFastPurgeResults(&FastPurgeResult{PurgeID: "purge-1"}, []string{"https://www.example.com/"})
+	assert.Equal(t, []string{"purge-1"}, results.PurgeIDs)
+	assert.False(t, results.SubmittedAt.IsZero())
+
+	report, err := apiClient.WaitForPurge(results, PurgeWaitOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, report.Completed())
+		assert.Equal(t, []string{"purge-1"}, report.PurgeIDs)
+		assert.Equal(t, "https://www.example.com/", report.Objects[0].Object)
+	}
+
+	assert.Empty(t, NewFastPurgeResults(nil, nil).PurgeIDs)
+}
+
+func TestPurgeQueueCoalescesObjects(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var urls, tags []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/url/production", purgeResponder(t, &urls))
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/tag/production", purgeResponder(t, &tags))
+
+	queue, err := apiClient.NewPurgeQueue(PurgeQueueOptions{Window: 20 * time.Millisecond})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, queue.Add(NewURLPurge().WithHostname("www.example.com").WithURLs("/a.js", "/b.js"), Production, Invalidate))
+		assert.NoError(t, queue.Add(NewCacheTagPurge("products"), Production, Invalidate))
+	}
+	assert.Equal(t, 3, queue.Pending(), "Duplicates should be removed")
+	assert.Error(t, queue.Add(NewCacheTagPurge("bad tag"), Production, Invalidate))
+
+	var results []PurgeQueueResult
+	for len(results) < 2 {
+		select {
+		case r := <-queue.Results():
+			results = append(results, r)
+		case <-time.After(time.Second):
+			t.Fatal("Queue should be flushed after window")
+		}
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, []string{"/a.js", "/b.js"}, results[0].Objects)
+	assert.Equal(t, "www.example.com", results[0].Hostname)
+	assert.Equal(t, []string{"purge-1"}, results[0].Results.PurgeIDs)
+	assert.Equal(t, []string{`{"hostname":"www.example.com","objects":["/a.js","/b.js"]}`}, urls)
+	assert.Equal(t, []string{`{"objects":["products"]}`}, tags)
+
+	assert.NoError(t, queue.Close(context.Background()))
+	_, open := <-queue.Results()
+	assert.False(t, open, "Results channel should be closed")
+}
+
+func TestPurgeQueueDropsUndrainedResults(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var urls, tags []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/url/production", purgeResponder(t, &urls))
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/invalidate/tag/production", purgeResponder(t, &tags))
+
+	queue, err := apiClient.NewPurgeQueue(PurgeQueueOptions{Window: time.Hour, ResultsBuffer: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, queue.Add(NewURLPurge().WithURLs("https://www.example.com/a.js"), Production, Invalidate))
+	assert.NoError(t, queue.Flush(context.Background()))
+
+	// Results buffer is full, final flush of Close must not wait for it to be drained
+	assert.NoError(t, queue.Add(NewCacheTagPurge("products"), Production, Invalidate))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	closed := make(chan error, 1)
+	go func() { closed <- queue.Close(ctx) }()
+
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close should not block on undrained results")
+	}
+
+	assert.Equal(t, 1, queue.Dropped())
+	assert.Len(t, tags, 1, "Dropped result should still be purged")
+
+	r, open := <-queue.Results()
+	if assert.True(t, open) {
+		assert.Equal(t, []string{"https://www.example.com/a.js"}, r.Objects)
+	}
+	_, open = <-queue.Results()
+	assert.False(t, open, "Results channel should be closed")
+}
+
+func TestPurgeQueueJournal(t *testing.T) {
+	apiClient := setupEdgeClient()
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/delete/cpcode/staging",
+		httpmock.NewStringResponder(503, `{"title":"Service Unavailable","httpStatus":503}`))
+
+	dir, err := ioutil.TempDir("", "purge-queue")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	journal := filepath.Join(dir, "purge.journal")
+
+	var failed []PurgeQueueResult
+	queue, err := apiClient.NewPurgeQueue(PurgeQueueOptions{
+		Window:      time.Hour,
+		JournalPath: journal,
+		OnResult:    func(r PurgeQueueResult) { failed = append(failed, r) },
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, queue.Add(NewCPCodePurge(12345, 67890), Staging, Delete))
+	assert.Error(t, queue.Close(context.Background()))
+	if assert.Len(t, failed, 1) {
+		assert.True(t, failed[0].Retry, "Unavailable API should be retried")
+	}
+
+	// Next run purges objects left by previous one
+	var bodies []string
+	httpmock.RegisterResponder("POST", "http://test.local/ccu/v3/delete/cpcode/staging", purgeResponder(t, &bodies))
+
+	var done []PurgeQueueResult
+	queue, err = apiClient.NewPurgeQueue(PurgeQueueOptions{
+		Window:      time.Hour,
+		JournalPath: journal,
+		OnResult:    func(r PurgeQueueResult) { done = append(done, r) },
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, queue.Pending(), "Pending objects should be restored")
+	assert.NoError(t, queue.Flush(context.Background()))
+	assert.Equal(t, []string{`{"objects":[12345,67890]}`}, bodies)
+	assert.Equal(t, 0, queue.Pending())
+	assert.NoError(t, queue.Close(context.Background()))
+
+	content, err := ioutil.ReadFile(journal)
+	assert.NoError(t, err)
+	assert.Empty(t, content, "Journal should be empty when everything was purged")
+}
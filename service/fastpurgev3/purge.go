@@ -0,0 +1,384 @@
+package fastpurgev3
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxRequestBodySize is the size limit of purge request body in bytes
+// enforced by Fast Purge API.
+const MaxRequestBodySize = 50000
+
+// MaxCacheTagLength is the maximum length of a single cache tag.
+const MaxCacheTagLength = 128
+
+var (
+	// cacheTagPattern matches characters allowed in cache tags
+	cacheTagPattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+\\-.^_`|~]+$")
+
+	// arlPattern matches beginning of ARL i.e. `/L/` or `/7/`
+	arlPattern = regexp.MustCompile(`^/[A-Za-z0-9]/`)
+)
+
+// PurgeRequest is implemented by typed purge requests built with
+// NewURLPurge, NewCPCodePurge and NewCacheTagPurge.
+type PurgeRequest interface {
+	// PurgeType returns endpoint used to purge the objects
+	PurgeType() AkamaiPurgeType
+
+	// Objects returns purged objects as they are sent to API
+	Objects() []string
+
+	// Validate checks that request has objects and all of them are valid
+	Validate() error
+
+	// Batches splits request into requests whose body does not exceed limit bytes
+	Batches(limit int) ([]PurgeRequest, error)
+}
+
+// URLPurge purges objects by URL, path on hostname or ARL.
+//
+//	req := fastpurgev3.NewURLPurge().
+//		WithURLs("https://www.example.com/index.html", "https://www.example.com/app.js")
+type URLPurge struct {
+	hostname string
+	objects  []urlObject
+}
+
+// urlObject is single URL, path or ARL to purge
+type urlObject struct {
+	value string
+	arl   bool
+}
+
+// NewURLPurge returns empty request to purge objects by URL.
+func NewURLPurge() *URLPurge {
+	return &URLPurge{}
+}
+
+// WithHostname sets hostname of objects given as paths and returns a URLPurge pointer.
+func (p *URLPurge) WithHostname(hostname string) *URLPurge {
+	p.hostname = hostname
+	return p
+}
+
+// WithURLs adds absolute URLs or, when hostname is set, paths starting
+// with `/` and returns a URLPurge pointer.
+func (p *URLPurge) WithURLs(urls ...string) *URLPurge {
+	for _, u := range urls {
+		p.objects = append(p.objects, urlObject{value: u})
+	}
+	return p
+}
+
+// WithARLs adds Akamai Resource Locators i.e. `/L/1234/5678/1d/www.example.com/index.html`
+// and returns a URLPurge pointer. ARLs cannot be combined with hostname.
+func (p *URLPurge) WithARLs(arls ...string) *URLPurge {
+	for _, a := range arls {
+		p.objects = append(p.objects, urlObject{value: a, arl: true})
+	}
+	return p
+}
+
+// Objects returns URLs, paths and ARLs to purge.
+func (p *URLPurge) Objects() []string {
+	objects := make([]string, len(p.objects))
+	for i, o := range p.objects {
+		objects[i] = o.value
+	}
+	return objects
+}
+
+// PurgeType returns URL purge type.
+func (p *URLPurge) PurgeType() AkamaiPurgeType {
+	return URL
+}
+
+// Validate checks that URLs are absolute, paths are used only with hostname
+// and ARLs only without it.
+func (p *URLPurge) Validate() error {
+	if len(p.objects) == 0 {
+		return errNoObjects()
+	}
+
+	for _, o := range p.objects {
+		if err := p.validateObject(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateObject checks single URL, path or ARL
+func (p *URLPurge) validateObject(o urlObject) error {
+	switch {
+	case o.arl:
+		if p.hostname != "" {
+			return errInvalidObject("ARL cannot be purged together with hostname", o.value)
+		}
+		if !arlPattern.MatchString(o.value) {
+			return errInvalidObject("Invalid ARL", o.value)
+		}
+	case p.hostname != "":
+		if !strings.HasPrefix(o.value, "/") {
+			return errInvalidObject("Path has to start with / when hostname is set", o.value)
+		}
+	default:
+		u, err := url.Parse(o.value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errInvalidObject("Invalid URL, absolute http(s) URL is required", o.value)
+		}
+	}
+
+	return nil
+}
+
+// urlPurgeBody is body of URL purge request, objects are always present
+type urlPurgeBody struct {
+	Hostname string   `json:"hostname,omitempty"`
+	Objects  []string `json:"objects"`
+}
+
+// MarshalJSON returns purge request body.
+func (p *URLPurge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(urlPurgeBody{
+		Hostname: p.hostname,
+		Objects:  p.Objects(),
+	})
+}
+
+// Batches splits request into requests whose body does not exceed limit bytes.
+func (p *URLPurge) Batches(limit int) ([]PurgeRequest, error) {
+	overhead, err := json.Marshal(urlPurgeBody{Hostname: p.hostname, Objects: []string{}})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := p.Objects()
+	ranges, err := batchRanges(objects, stringSizes(objects), len(overhead), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]PurgeRequest, len(ranges))
+	for i, r := range ranges {
+		batches[i] = &URLPurge{hostname: p.hostname, objects: p.objects[r[0]:r[1]:r[1]]}
+	}
+
+	return batches, nil
+}
+
+// CPCodePurge purges all content of CP codes.
+//
+//	req := fastpurgev3.NewCPCodePurge(12345, 67890)
+type CPCodePurge struct {
+	cpCodes []int
+}
+
+// NewCPCodePurge returns request to purge content of given CP codes.
+func NewCPCodePurge(cpCodes ...int) *CPCodePurge {
+	return &CPCodePurge{cpCodes: cpCodes}
+}
+
+// WithCPCodes adds CP codes and returns a CPCodePurge pointer.
+func (p *CPCodePurge) WithCPCodes(cpCodes ...int) *CPCodePurge {
+	p.cpCodes = append(p.cpCodes, cpCodes...)
+	return p
+}
+
+// CPCodes returns CP codes to purge.
+func (p *CPCodePurge) CPCodes() []int {
+	return p.cpCodes
+}
+
+// Objects returns CP codes to purge as strings.
+func (p *CPCodePurge) Objects() []string {
+	objects := make([]string, len(p.cpCodes))
+	for i, code := range p.cpCodes {
+		objects[i] = strconv.Itoa(code)
+	}
+	return objects
+}
+
+// PurgeType returns CP code purge type.
+func (p *CPCodePurge) PurgeType() AkamaiPurgeType {
+	return CPCode
+}
+
+// Validate checks that all CP codes are positive numbers.
+func (p *CPCodePurge) Validate() error {
+	if len(p.cpCodes) == 0 {
+		return errNoObjects()
+	}
+
+	for _, code := range p.cpCodes {
+		if code <= 0 {
+			return errInvalidObject("Invalid CP code", strconv.Itoa(code))
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON returns purge request body.
+func (p *CPCodePurge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Objects []int `json:"objects"`
+	}{p.cpCodes})
+}
+
+// Batches splits request into requests whose body does not exceed limit bytes.
+func (p *CPCodePurge) Batches(limit int) ([]PurgeRequest, error) {
+	objects := p.Objects()
+	sizes := make([]int, len(objects))
+	for i, o := range objects {
+		sizes[i] = len(o)
+	}
+
+	ranges, err := batchRanges(objects, sizes, len(`{"objects":[]}`), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]PurgeRequest, len(ranges))
+	for i, r := range ranges {
+		batches[i] = &CPCodePurge{cpCodes: p.cpCodes[r[0]:r[1]:r[1]]}
+	}
+
+	return batches, nil
+}
+
+// CacheTagPurge purges content labeled with cache tags.
+//
+//	req := fastpurgev3.NewCacheTagPurge("products", "prices")
+type CacheTagPurge struct {
+	tags []string
+}
+
+// NewCacheTagPurge returns request to purge content labeled with given tags.
+func NewCacheTagPurge(tags ...string) *CacheTagPurge {
+	return &CacheTagPurge{tags: tags}
+}
+
+// WithTags adds cache tags and returns a CacheTagPurge pointer.
+func (p *CacheTagPurge) WithTags(tags ...string) *CacheTagPurge {
+	p.tags = append(p.tags, tags...)
+	return p
+}
+
+// Tags returns cache tags to purge.
+func (p *CacheTagPurge) Tags() []string {
+	return p.tags
+}
+
+// Objects returns cache tags to purge.
+func (p *CacheTagPurge) Objects() []string {
+	return p.tags
+}
+
+// PurgeType returns cache tag purge type.
+func (p *CacheTagPurge) PurgeType() AkamaiPurgeType {
+	return CacheTag
+}
+
+// Validate checks that tags are at most 128 characters long and contain
+// only alphanumeric characters and !#$%&'*+-.^_`|~
+func (p *CacheTagPurge) Validate() error {
+	if len(p.tags) == 0 {
+		return errNoObjects()
+	}
+
+	for _, tag := range p.tags {
+		if len(tag) > MaxCacheTagLength || !cacheTagPattern.MatchString(tag) {
+			return errInvalidObject("Invalid cache tag", tag)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON returns purge request body.
+func (p *CacheTagPurge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Objects []string `json:"objects"`
+	}{p.tags})
+}
+
+// Batches splits request into requests whose body does not exceed limit bytes.
+func (p *CacheTagPurge) Batches(limit int) ([]PurgeRequest, error) {
+	ranges, err := batchRanges(p.tags, stringSizes(p.tags), len(`{"objects":[]}`), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]PurgeRequest, len(ranges))
+	for i, r := range ranges {
+		batches[i] = &CacheTagPurge{tags: p.tags[r[0]:r[1]:r[1]]}
+	}
+
+	return batches, nil
+}
+
+// batchRanges splits objects into consecutive ranges so that JSON body made of
+// overhead bytes and array of objects with given encoded sizes stays within limit.
+func batchRanges(objects []string, sizes []int, overhead, limit int) ([][2]int, error) {
+	var ranges [][2]int
+
+	start, size := 0, overhead
+	for i, n := range sizes {
+		if overhead+n > limit {
+			return nil, ErrorPurgeRequest{
+				ErrorMessage: "Purge object exceeds request body size limit",
+				ErrorType:    "ErrorPurgeRequestTooLarge",
+				Object:       objects[i],
+			}
+		}
+
+		// Objects after the first one are separated by comma
+		if i > start && size+n+1 > limit {
+			ranges = append(ranges, [2]int{start, i})
+			start, size = i, overhead
+		}
+
+		if i > start {
+			size++
+		}
+		size += n
+	}
+
+	if start < len(sizes) {
+		ranges = append(ranges, [2]int{start, len(sizes)})
+	}
+
+	return ranges, nil
+}
+
+// stringSizes returns sizes of strings encoded as JSON
+func stringSizes(objects []string) []int {
+	sizes := make([]int, len(objects))
+	for i, o := range objects {
+		encoded, _ := json.Marshal(o)
+		sizes[i] = len(encoded)
+	}
+
+	return sizes
+}
+
+func errNoObjects() error {
+	return ErrorPurgeRequest{
+		ErrorMessage: "Purge request has no objects",
+		ErrorType:    "ErrorPurgeRequestEmpty",
+	}
+}
+
+func errInvalidObject(msg, object string) error {
+	return ErrorPurgeRequest{
+		ErrorMessage: msg,
+		ErrorType:    "ErrorPurgeRequestObject",
+		Object:       object,
+	}
+}
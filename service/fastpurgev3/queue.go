@@ -0,0 +1,564 @@
+package fastpurgev3
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+)
+
+// defaultQueueWindow is time objects are collected before they are purged
+const defaultQueueWindow = 5 * time.Second
+
+// PurgeQueueOptions configures PurgeQueue.
+type PurgeQueueOptions struct {
+	// Window is time objects are collected before they are purged, 5 seconds by default
+	Window time.Duration
+
+	// JournalPath is file where pending objects are persisted so that they are
+	// purged after restart. Pending work is not persisted when empty.
+	JournalPath string
+
+	// OnResult is called with result of every purge sent by the queue. When nil
+	// results are delivered to channel returned by Results which has to be drained,
+	// results which do not fit into its buffer are dropped, see Dropped.
+	OnResult func(PurgeQueueResult)
+
+	// ResultsBuffer is capacity of results channel, 100 by default
+	ResultsBuffer int
+}
+
+// PurgeQueueResult is the result of objects purged together by PurgeQueue.
+type PurgeQueueResult struct {
+	Type     AkamaiPurgeType
+	Hostname string
+	Tier     AkamaiEnvironment
+	Strategy AkamaiPurgeStrategy
+	Objects  []string
+
+	// Results of batches accepted by API, can be partial when Err is set
+	Results *FastPurgeResults
+
+	// Err is set when purge failed
+	Err error
+
+	// Retry reports whether error is temporary, objects which were not purged
+	// are then kept in the queue and purged again in the next window
+	Retry bool
+}
+
+// PurgeQueue collects purge objects over a time window, removes duplicates and
+// coalesces them into as few purge requests as possible per purge type,
+// hostname, network and strategy.
+//
+//	queue, err := svc.NewPurgeQueue(fastpurgev3.PurgeQueueOptions{JournalPath: "/var/lib/deploy/purge.journal"})
+//	if err != nil {
+//		// handle error
+//	}
+//	defer queue.Close(ctx)
+//
+//	queue.Add(fastpurgev3.NewURLPurge().WithURLs(url), fastpurgev3.Production, fastpurgev3.Invalidate)
+type PurgeQueue struct {
+	fp   *Fastpurgev3
+	opts PurgeQueueOptions
+
+	mu      sync.Mutex
+	groups  map[queueKey]*queueGroup
+	order   []queueKey
+	timer   *time.Timer
+	journal *os.File
+	closed  bool
+
+	// flushMu serializes flushes and guards results channel
+	flushMu sync.Mutex
+	results chan PurgeQueueResult
+	done    bool
+
+	// dropped counts results which did not fit into results channel
+	dropped int64
+}
+
+// queueKey identifies objects which can be purged by single request
+type queueKey struct {
+	Type     AkamaiPurgeType     `json:"type"`
+	Hostname string              `json:"hostname,omitempty"`
+	Tier     AkamaiEnvironment   `json:"tier"`
+	Strategy AkamaiPurgeStrategy `json:"strategy"`
+}
+
+// queueEntry is single queued object, also used as journal record
+type queueEntry struct {
+	queueKey
+	Object string `json:"object"`
+	ARL    bool   `json:"arl,omitempty"`
+}
+
+// queueGroup holds unique objects of the same key in order they were added
+type queueGroup struct {
+	entries []queueEntry
+	seen    map[string]struct{}
+}
+
+// NewPurgeQueue creates purge queue. Objects left in the journal by previous
+// run are purged in the first window.
+func (fp *Fastpurgev3) NewPurgeQueue(opts PurgeQueueOptions) (*PurgeQueue, error) {
+	if opts.Window <= 0 {
+		opts.Window = defaultQueueWindow
+	}
+	if opts.ResultsBuffer <= 0 {
+		opts.ResultsBuffer = 100
+	}
+
+	q := &PurgeQueue{
+		fp:      fp,
+		opts:    opts,
+		groups:  map[queueKey]*queueGroup{},
+		results: make(chan PurgeQueueResult, opts.ResultsBuffer),
+	}
+
+	if opts.JournalPath == "" {
+		return q, nil
+	}
+
+	entries, err := readJournal(opts.JournalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		q.enqueue(e)
+	}
+
+	// Compact journal so that duplicates from previous run are dropped
+	if err := q.rewriteJournal(); err != nil {
+		return nil, err
+	}
+
+	if len(q.order) > 0 {
+		fp.Client.Logger.Infof("Restored %d pending purge objects from %s", len(entries), opts.JournalPath)
+		q.timer = time.AfterFunc(opts.Window, q.flushOnTimer)
+	}
+
+	return q, nil
+}
+
+// Add validates request and queues its objects. Objects already waiting
+// in the queue with the same purge type, hostname, network and strategy are ignored.
+func (q *PurgeQueue) Add(req PurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	entries, err := queueEntries(req, tier, purgeStrategy)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrorPurgeRequest{
+			ErrorMessage: "Purge queue is closed",
+			ErrorType:    "ErrorPurgeQueueClosed",
+		}
+	}
+
+	var added []queueEntry
+	for _, e := range entries {
+		if q.enqueue(e) {
+			added = append(added, e)
+		}
+	}
+
+	if err := q.appendJournal(added); err != nil {
+		return err
+	}
+
+	if q.timer == nil && len(q.order) > 0 {
+		q.timer = time.AfterFunc(q.opts.Window, q.flushOnTimer)
+	}
+
+	return nil
+}
+
+// Pending returns number of objects waiting in the queue.
+func (q *PurgeQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count := 0
+	for _, g := range q.groups {
+		count += len(g.entries)
+	}
+	return count
+}
+
+// Results returns channel with purge results, used when OnResult is not set.
+// Channel is closed by Close.
+func (q *PurgeQueue) Results() <-chan PurgeQueueResult {
+	return q.results
+}
+
+// Dropped returns number of results which were dropped because results
+// channel was full.
+func (q *PurgeQueue) Dropped() int {
+	return int(atomic.LoadInt64(&q.dropped))
+}
+
+// Flush purges queued objects without waiting for the window to elapse.
+// Error is returned when any of the purges failed, details are in results.
+func (q *PurgeQueue) Flush(ctx context.Context) error {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	if q.done {
+		return nil
+	}
+
+	return q.flush(ctx)
+}
+
+// flush purges queued objects, caller holds flushMu
+func (q *PurgeQueue) flush(ctx context.Context) error {
+	q.mu.Lock()
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	groups, order := q.groups, q.order
+	q.groups, q.order = map[queueKey]*queueGroup{}, nil
+	q.mu.Unlock()
+
+	var firstErr error
+	var retry []queueEntry
+
+	for _, key := range order {
+		g := groups[key]
+
+		result := PurgeQueueResult{
+			Type:     key.Type,
+			Hostname: key.Hostname,
+			Tier:     key.Tier,
+			Strategy: key.Strategy,
+			Objects:  make([]string, len(g.entries)),
+		}
+		for i, e := range g.entries {
+			result.Objects[i] = e.Object
+		}
+
+		result.Results, result.Err = q.fp.PurgeWithContext(ctx, g.request(key), key.Tier, key.Strategy)
+		if result.Err != nil {
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+
+			result.Retry = retryablePurgeError(result.Err)
+			if result.Retry {
+				retry = append(retry, g.unsent(result.Results)...)
+			}
+
+			q.fp.Client.Logger.Warnf("Purge of %d objects failed ( retry: %t ): %s", len(g.entries), result.Retry, result.Err)
+		}
+
+		q.deliver(result)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, e := range retry {
+		q.enqueue(e)
+	}
+
+	if err := q.rewriteJournal(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	if q.timer == nil && len(q.order) > 0 && !q.closed {
+		q.timer = time.AfterFunc(q.opts.Window, q.flushOnTimer)
+	}
+
+	return firstErr
+}
+
+// Close purges queued objects and stops the queue. Objects which could
+// not be purged stay in the journal and are purged by the next queue.
+func (q *PurgeQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	err := q.flush(ctx)
+
+	q.done = true
+	close(q.results)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+
+	if q.journal != nil {
+		if cerr := q.journal.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		q.journal = nil
+	}
+
+	return err
+}
+
+// flushOnTimer flushes queue when window elapses
+func (q *PurgeQueue) flushOnTimer() {
+	if err := q.Flush(context.Background()); err != nil {
+		q.fp.Client.Logger.Debugf("Purge queue flush finished with error: %s", err)
+	}
+}
+
+// deliver passes result to callback or results channel. Result is dropped
+// when channel is full, so flush never waits for results to be drained.
+func (q *PurgeQueue) deliver(result PurgeQueueResult) {
+	if q.opts.OnResult != nil {
+		q.opts.OnResult(result)
+		return
+	}
+
+	select {
+	case q.results <- result:
+	default:
+		atomic.AddInt64(&q.dropped, 1)
+		q.fp.Client.Logger.Warnf("Purge queue results channel is full, result of %d objects was dropped", len(result.Objects))
+	}
+}
+
+// enqueue adds entry unless it is already queued, caller holds the lock
+func (q *PurgeQueue) enqueue(e queueEntry) bool {
+	g, ok := q.groups[e.queueKey]
+	if !ok {
+		g = &queueGroup{seen: map[string]struct{}{}}
+		q.groups[e.queueKey] = g
+		q.order = append(q.order, e.queueKey)
+	}
+
+	if _, dup := g.seen[e.Object]; dup {
+		return false
+	}
+
+	g.seen[e.Object] = struct{}{}
+	g.entries = append(g.entries, e)
+
+	return true
+}
+
+// appendJournal persists added entries, caller holds the lock
+func (q *PurgeQueue) appendJournal(entries []queueEntry) error {
+	if q.opts.JournalPath == "" || len(entries) == 0 {
+		return nil
+	}
+
+	if q.journal == nil {
+		f, err := os.OpenFile(q.opts.JournalPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		q.journal = f
+	}
+
+	var buf []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	if _, err := q.journal.Write(buf); err != nil {
+		return err
+	}
+
+	return q.journal.Sync()
+}
+
+// rewriteJournal atomically replaces journal with queued entries, caller holds the lock
+func (q *PurgeQueue) rewriteJournal() error {
+	if q.opts.JournalPath == "" {
+		return nil
+	}
+
+	if q.journal != nil {
+		q.journal.Close()
+		q.journal = nil
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(q.opts.JournalPath), filepath.Base(q.opts.JournalPath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, key := range q.order {
+		for _, e := range q.groups[key].entries {
+			if err := enc.Encode(e); err != nil {
+				tmp.Close()
+				return err
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), q.opts.JournalPath)
+}
+
+// readJournal returns entries persisted in journal, missing journal is empty
+func readJournal(path string) ([]queueEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []queueEntry
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), MaxRequestBodySize)
+	for scanner.Scan() {
+		var e queueEntry
+		// Last line can be incomplete when process crashed while writing it
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, scanner.Err()
+}
+
+// queueEntries splits typed request into queue entries
+func queueEntries(req PurgeRequest, tier AkamaiEnvironment, purgeStrategy AkamaiPurgeStrategy) ([]queueEntry, error) {
+	key := queueKey{Type: req.PurgeType(), Tier: tier, Strategy: purgeStrategy}
+
+	var entries []queueEntry
+	switch r := req.(type) {
+	case *URLPurge:
+		key.Hostname = r.hostname
+		for _, o := range r.objects {
+			entries = append(entries, queueEntry{queueKey: key, Object: o.value, ARL: o.arl})
+		}
+	case *CPCodePurge, *CacheTagPurge:
+		for _, o := range req.Objects() {
+			entries = append(entries, queueEntry{queueKey: key, Object: o})
+		}
+	default:
+		return nil, ErrorPurgeRequest{
+			ErrorMessage: "Purge request type is not supported by queue",
+			ErrorType:    "ErrorPurgeRequestType",
+		}
+	}
+
+	return entries, nil
+}
+
+// request builds purge request of all entries in the group
+func (g *queueGroup) request(key queueKey) PurgeRequest {
+	switch key.Type {
+	case CPCode:
+		req := NewCPCodePurge()
+		for _, o := range g.objects() {
+			// Entries were validated when added, journal could be edited by hand
+			if code, err := strconv.Atoi(o); err == nil {
+				req.WithCPCodes(code)
+			}
+		}
+		return req
+	case CacheTag:
+		return NewCacheTagPurge(g.objects()...)
+	}
+
+	req := NewURLPurge().WithHostname(key.Hostname)
+	for _, e := range g.entries {
+		req.objects = append(req.objects, urlObject{value: e.Object, arl: e.ARL})
+	}
+	return req
+}
+
+// objects returns objects of the group
+func (g *queueGroup) objects() []string {
+	objects := make([]string, len(g.entries))
+	for i, e := range g.entries {
+		objects[i] = e.Object
+	}
+	return objects
+}
+
+// unsent returns entries which were not part of accepted batches
+func (g *queueGroup) unsent(results *FastPurgeResults) []queueEntry {
+	if results == nil {
+		return g.entries
+	}
+
+	sent := map[string]struct{}{}
+	for _, o := range results.Objects {
+		sent[o] = struct{}{}
+	}
+
+	var unsent []queueEntry
+	for _, e := range g.entries {
+		if _, ok := sent[e.Object]; !ok {
+			unsent = append(unsent, e)
+		}
+	}
+	return unsent
+}
+
+// retryablePurgeError reports whether purge can succeed when sent again
+func retryablePurgeError(err error) bool {
+	var reqErr ErrorPurgeRequest
+	if errors.As(err, &reqErr) {
+		return false
+	}
+
+	var apiErr *edgegrid.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status == 429 || apiErr.Status >= 500
+	}
+
+	// Network errors and cancelled flushes
+	return true
+}
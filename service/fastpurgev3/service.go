@@ -8,6 +8,13 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/ccu/v3"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "fastpurgev3"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 20
+	defaultRateBurst = 50
 )
 
 // Fastpurgev3 provides the API operation methods for making requests to
@@ -19,20 +26,25 @@ type Fastpurgev3 struct {
 
 // New creates a new instance of the Fastpurgev3 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     // Create a Fastpurgev3 client from just a config.
-//     svc := Fastpurgev3.New(myConfig))
-func New(cfgs *edgegrid.Config) *Fastpurgev3 {
+//     svc, err := Fastpurgev3.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Fastpurgev3, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Fastpurgev3 {
-	svc := &Fastpurgev3{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Fastpurgev3, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Fastpurgev3{Client: c}, nil
 }
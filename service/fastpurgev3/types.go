@@ -1,5 +1,7 @@
 package fastpurgev3
 
+import "time"
+
 // AkamaiEnvironment represents Akamai's target environment type.
 type AkamaiEnvironment string
 
@@ -32,3 +34,52 @@ type FastPurgeResult struct {
 	SupportID        string `json:"supportId"`
 	Detail           string `json:"detail"`
 }
+
+// FastPurgeResults aggregates results of purge request sent in one or more batches.
+type FastPurgeResults struct {
+	// Results holds result of every batch in order the batches were sent
+	Results []FastPurgeResult
+
+	// PurgeIDs lists IDs of all submitted purges
+	PurgeIDs []string
+
+	// EstimatedSeconds is the longest estimate of all batches
+	EstimatedSeconds int64
+
+	// Objects lists objects of all submitted batches
+	Objects []string
+
+	// SubmittedAt is the time last batch was accepted, estimate is counted from it
+	SubmittedAt time.Time
+}
+
+// NewFastPurgeResults returns results of single purge i.e. returned by PurgeCacheByURL,
+// so it can be passed to WaitForPurge. Objects are the purged objects, estimate is
+// counted from now.
+//
+//	result, err := api.PurgeCacheByURL(opts, fastpurgev3.Production, fastpurgev3.Invalidate)
+//	if err != nil {
+//		return err
+//	}
+//
+//	report, err := api.WaitForPurge(fastpurgev3.NewFastPurgeResults(result, opts.Objects), fastpurgev3.PurgeWaitOptions{})
+func NewFastPurgeResults(result *FastPurgeResult, objects []string) *FastPurgeResults {
+	results := &FastPurgeResults{}
+	if result != nil {
+		results.add(*result, objects)
+	}
+
+	return results
+}
+
+// add appends result of a single batch
+func (r *FastPurgeResults) add(result FastPurgeResult, objects []string) {
+	r.Results = append(r.Results, result)
+	r.PurgeIDs = append(r.PurgeIDs, result.PurgeID)
+	r.Objects = append(r.Objects, objects...)
+	r.SubmittedAt = time.Now()
+
+	if result.EstimatedSeconds > r.EstimatedSeconds {
+		r.EstimatedSeconds = result.EstimatedSeconds
+	}
+}
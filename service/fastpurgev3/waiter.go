@@ -0,0 +1,241 @@
+package fastpurgev3
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
+
+const (
+	// defaultVerifyInterval is time between verification rounds
+	defaultVerifyInterval = 5 * time.Second
+
+	// defaultVerifyAttempts is number of verification rounds before giving up
+	defaultVerifyAttempts = 12
+)
+
+// PurgeVerifier reports whether HEAD response of verification URL shows
+// content fetched after the purge was submitted.
+type PurgeVerifier func(resp *http.Response, submittedAt time.Time) bool
+
+// PurgeWaitOptions configures WaitForPurge.
+type PurgeWaitOptions struct {
+	// VerifyURLs are requested with HEAD once the estimate elapses. When empty
+	// purge is considered complete as soon as the estimate elapses.
+	VerifyURLs []string
+
+	// Verifier checks verification responses, DefaultPurgeVerifier is used when nil
+	Verifier PurgeVerifier
+
+	// Interval is time between verification rounds, 5 seconds by default
+	Interval time.Duration
+
+	// MaxAttempts is number of verification rounds, 12 by default
+	MaxAttempts int
+
+	// HTTPClient sends verification requests, http.DefaultClient is used when nil
+	HTTPClient *http.Client
+}
+
+// PurgeReport describes completion of purge returned by WaitForPurge.
+type PurgeReport struct {
+	PurgeIDs []string
+
+	// Objects lists completion of verification URLs or, when none were set,
+	// of purged objects
+	Objects []PurgeObjectStatus
+
+	// Waited is the total time spent waiting
+	Waited time.Duration
+}
+
+// PurgeObjectStatus describes completion of single object.
+type PurgeObjectStatus struct {
+	Object      string
+	Completed   bool
+	CompletedAt time.Time
+
+	// Attempts is number of verification requests sent for the object
+	Attempts int
+
+	// StatusCode is status of last verification response
+	StatusCode int
+
+	// Err is error of last verification request
+	Err error
+}
+
+// Completed reports whether all objects are completed.
+func (r *PurgeReport) Completed() bool {
+	for _, o := range r.Objects {
+		if !o.Completed {
+			return false
+		}
+	}
+	return true
+}
+
+// Pending returns objects which are not completed.
+func (r *PurgeReport) Pending() []string {
+	var pending []string
+	for _, o := range r.Objects {
+		if !o.Completed {
+			pending = append(pending, o.Object)
+		}
+	}
+	return pending
+}
+
+// DefaultPurgeVerifier considers object purged when edge response was cached
+// after the purge was submitted, based on `Date` and `Age` headers.
+func DefaultPurgeVerifier(resp *http.Response, submittedAt time.Time) bool {
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return false
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		date = time.Now()
+	}
+
+	age, _ := strconv.Atoi(resp.Header.Get("Age"))
+	cachedAt := date.Add(-time.Duration(age) * time.Second)
+
+	// Date header has seconds precision
+	return !cachedAt.Before(submittedAt.Truncate(time.Second))
+}
+
+// WaitForPurge blocks until estimated time of purge elapses and, when verification
+// URLs are set, until every URL returns content fetched after the purge. Report is
+// returned also with error so that pending objects can be inspected. Result of single
+// purge is waited for with NewFastPurgeResults.
+func (fp *Fastpurgev3) WaitForPurge(results *FastPurgeResults, opts PurgeWaitOptions) (*PurgeReport, error) {
+	return fp.WaitForPurgeWithContext(context.Background(), results, opts)
+}
+
+// WaitForPurgeWithContext is the same as WaitForPurge with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (fp *Fastpurgev3) WaitForPurgeWithContext(ctx context.Context, results *FastPurgeResults, opts PurgeWaitOptions) (*PurgeReport, error) {
+	if results == nil {
+		return nil, ErrorPurgeRequest{
+			ErrorMessage: "Cannot wait for purge without results",
+			ErrorType:    "ErrorPurgeRequestEmpty",
+		}
+	}
+
+	start := time.Now()
+
+	report := &PurgeReport{PurgeIDs: results.PurgeIDs}
+
+	objects := opts.VerifyURLs
+	if len(objects) == 0 {
+		objects = results.Objects
+	}
+	for _, o := range objects {
+		report.Objects = append(report.Objects, PurgeObjectStatus{Object: o})
+	}
+
+	submittedAt := results.SubmittedAt
+	if submittedAt.IsZero() {
+		submittedAt = start
+	}
+
+	estimate := time.Until(submittedAt.Add(time.Duration(results.EstimatedSeconds) * time.Second))
+	if estimate > 0 {
+		fp.Client.Logger.Debugf("Waiting %s for purges %v to complete", estimate, results.PurgeIDs)
+		if err := client.SleepWithContext(ctx, estimate); err != nil {
+			report.Waited = time.Since(start)
+			return report, err
+		}
+	}
+
+	if len(opts.VerifyURLs) == 0 {
+		now := time.Now()
+		for i := range report.Objects {
+			report.Objects[i].Completed = true
+			report.Objects[i].CompletedAt = now
+		}
+		report.Waited = time.Since(start)
+
+		return report, nil
+	}
+
+	verifier := opts.Verifier
+	if verifier == nil {
+		verifier = DefaultPurgeVerifier
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultVerifyInterval
+	}
+
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultVerifyAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		for i := range report.Objects {
+			if !report.Objects[i].Completed {
+				fp.verifyObject(ctx, httpClient, verifier, submittedAt, &report.Objects[i])
+			}
+		}
+
+		if report.Completed() {
+			break
+		}
+
+		if attempt >= attempts {
+			report.Waited = time.Since(start)
+			return report, ErrorPurgeRequest{
+				ErrorMessage: "Purge was not verified for all objects",
+				ErrorType:    "ErrorPurgeNotVerified",
+			}
+		}
+
+		fp.Client.Logger.Debugf("Purge not verified for %d objects, checking again in %s", len(report.Pending()), interval)
+		if err := client.SleepWithContext(ctx, interval); err != nil {
+			report.Waited = time.Since(start)
+			return report, err
+		}
+	}
+
+	report.Waited = time.Since(start)
+
+	return report, nil
+}
+
+// verifyObject sends HEAD request to verification URL and updates its status
+func (fp *Fastpurgev3) verifyObject(ctx context.Context, httpClient *http.Client, verifier PurgeVerifier, submittedAt time.Time, status *PurgeObjectStatus) {
+	status.Attempts++
+
+	req, err := http.NewRequest(http.MethodHead, status.Object, nil)
+	if err != nil {
+		status.Err = err
+		return
+	}
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		status.Err = err
+		return
+	}
+	resp.Body.Close()
+
+	status.Err = nil
+	status.StatusCode = resp.StatusCode
+
+	if verifier(resp, submittedAt) {
+		status.Completed = true
+		status.CompletedAt = time.Now()
+	}
+}
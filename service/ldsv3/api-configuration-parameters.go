@@ -1,21 +1,32 @@
 package ldsv3
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // List calls
 
 // ListLogConfigurationParameter generic get log configuration parameters call
 func (lds *Ldsv3) ListLogConfigurationParameter(parameterType string) (*ConfigurationParameterResponse, error) {
+	return lds.ListLogConfigurationParameterWithContext(context.Background(), parameterType)
+}
+
+// ListLogConfigurationParameterWithContext is the same as ListLogConfigurationParameter with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogConfigurationParameterWithContext(ctx context.Context, parameterType string) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListLogConfigurationParameter")
 	if parameterType == "" {
-		return nil, fmt.Errorf("Please provide parameter type", parameterType)
+		return nil, fmt.Errorf("Please provide parameter type")
 	}
 
 	apiURI := fmt.Sprintf("%s/log-configuration-parameters/%s", basePath, parameterType)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(ConfigurationParameterResponse{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -25,9 +36,7 @@ func (lds *Ldsv3) ListLogConfigurationParameter(parameterType string) (*Configur
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*ConfigurationParameterResponse), nil
@@ -36,7 +45,14 @@ func (lds *Ldsv3) ListLogConfigurationParameter(parameterType string) (*Configur
 // ListDeliveryFrequencies returns all available delivery frequencies, each with an id and descriptive value.
 // You need the id to create or modify a log delivery configuration.
 func (lds *Ldsv3) ListDeliveryFrequencies() (*ConfigurationParameterResponse, error) {
-	resp, err := lds.ListLogConfigurationParameter("delivery-frequencies")
+	return lds.ListDeliveryFrequenciesWithContext(context.Background())
+}
+
+// ListDeliveryFrequenciesWithContext is the same as ListDeliveryFrequencies with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListDeliveryFrequenciesWithContext(ctx context.Context) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListDeliveryFrequencies")
+	resp, err := lds.ListLogConfigurationParameterWithContext(ctx, "delivery-frequencies")
 
 	if err != nil {
 		return nil, err
@@ -47,7 +63,14 @@ func (lds *Ldsv3) ListDeliveryFrequencies() (*ConfigurationParameterResponse, er
 
 // ListDeliveryThresholds returns all available log delivery thresholds, each with an id and descriptive value.
 func (lds *Ldsv3) ListDeliveryThresholds() (*ConfigurationParameterResponse, error) {
-	resp, err := lds.ListLogConfigurationParameter("delivery-thresholds")
+	return lds.ListDeliveryThresholdsWithContext(context.Background())
+}
+
+// ListDeliveryThresholdsWithContext is the same as ListDeliveryThresholds with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListDeliveryThresholdsWithContext(ctx context.Context) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListDeliveryThresholds")
+	resp, err := lds.ListLogConfigurationParameterWithContext(ctx, "delivery-thresholds")
 
 	if err != nil {
 		return nil, err
@@ -60,23 +83,26 @@ func (lds *Ldsv3) ListDeliveryThresholds() (*ConfigurationParameterResponse, err
 // You can restrict the response by specifying optional values for the deliveryType and logSourceType,
 // since available encoding types are based on these characteristics of a log delivery configuration.
 func (lds *Ldsv3) ListLogEncodings(deliveryType, logSourceType string) (*ConfigurationParameterResponse, error) {
-	apiURI := fmt.Sprintf("%s/log-configuration-parameters/encodings", basePath)
-
-	query := map[string]string{}
+	return lds.ListLogEncodingsWithContext(context.Background(), deliveryType, logSourceType)
+}
 
-	if deliveryType != "" {
-		query["deliveryType"] = deliveryType
-	}
+// ListLogEncodingsWithContext is the same as ListLogEncodings with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogEncodingsWithContext(ctx context.Context, deliveryType, logSourceType string) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListLogEncodings")
+	apiURI := fmt.Sprintf("%s/log-configuration-parameters/encodings", basePath)
 
-	if logSourceType != "" {
-		query["logSourceType"] = logSourceType
+	query, err := client.QueryParams(logEncodingsOptions{DeliveryType: deliveryType, LogSourceType: logSourceType})
+	if err != nil {
+		return nil, err
 	}
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(ConfigurationParameterResponse{}).
 		SetError(LsdErrorv3{}).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -84,9 +110,7 @@ func (lds *Ldsv3) ListLogEncodings(deliveryType, logSourceType string) (*Configu
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*ConfigurationParameterResponse), nil
@@ -95,7 +119,14 @@ func (lds *Ldsv3) ListLogEncodings(deliveryType, logSourceType string) (*Configu
 // ListMessageSizes returns all available message sizes, each with an id and descriptive value.
 // You need the id to create or modify a log delivery configuration.
 func (lds *Ldsv3) ListMessageSizes() (*ConfigurationParameterResponse, error) {
-	resp, err := lds.ListLogConfigurationParameter("message-sizes")
+	return lds.ListMessageSizesWithContext(context.Background())
+}
+
+// ListMessageSizesWithContext is the same as ListMessageSizes with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListMessageSizesWithContext(ctx context.Context) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListMessageSizes")
+	resp, err := lds.ListLogConfigurationParameterWithContext(ctx, "message-sizes")
 
 	if err != nil {
 		return nil, err
@@ -106,7 +137,14 @@ func (lds *Ldsv3) ListMessageSizes() (*ConfigurationParameterResponse, error) {
 
 // ListContacts returns all contacts to which you have access.
 func (lds *Ldsv3) ListContacts() (*ConfigurationParameterResponse, error) {
-	resp, err := lds.ListLogConfigurationParameter("contacts")
+	return lds.ListContactsWithContext(context.Background())
+}
+
+// ListContactsWithContext is the same as ListContacts with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListContactsWithContext(ctx context.Context) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListContacts")
+	resp, err := lds.ListLogConfigurationParameterWithContext(ctx, "contacts")
 
 	if err != nil {
 		return nil, err
@@ -117,7 +155,14 @@ func (lds *Ldsv3) ListContacts() (*ConfigurationParameterResponse, error) {
 
 // ListNetStorageGroups returns all NetStorage4 groups to which you have access.
 func (lds *Ldsv3) ListNetStorageGroups() (*ConfigurationParameterResponse, error) {
-	resp, err := lds.ListLogConfigurationParameter("netstorage-groups")
+	return lds.ListNetStorageGroupsWithContext(context.Background())
+}
+
+// ListNetStorageGroupsWithContext is the same as ListNetStorageGroups with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListNetStorageGroupsWithContext(ctx context.Context) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListNetStorageGroups")
+	resp, err := lds.ListLogConfigurationParameterWithContext(ctx, "netstorage-groups")
 
 	if err != nil {
 		return nil, err
@@ -128,18 +173,26 @@ func (lds *Ldsv3) ListNetStorageGroups() (*ConfigurationParameterResponse, error
 
 // GetLogConfigurationParameter generic get log configuration parameters call
 func (lds *Ldsv3) GetLogConfigurationParameter(ID, parameterType string) (*GenericConfigurationParameterElement, error) {
+	return lds.GetLogConfigurationParameterWithContext(context.Background(), ID, parameterType)
+}
+
+// GetLogConfigurationParameterWithContext is the same as GetLogConfigurationParameter with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogConfigurationParameterWithContext(ctx context.Context, ID, parameterType string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogConfigurationParameter")
 	if ID == "" {
 		return nil, fmt.Errorf("Please provide %s ID", parameterType)
 	}
 
 	if parameterType == "" {
-		return nil, fmt.Errorf("Please provide parameter type", parameterType)
+		return nil, fmt.Errorf("Please provide parameter type")
 	}
 
 	apiURI := fmt.Sprintf("%s/log-configuration-parameters/%s/%s", basePath, parameterType, ID)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(GenericConfigurationParameterElement{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -149,9 +202,7 @@ func (lds *Ldsv3) GetLogConfigurationParameter(ID, parameterType string) (*Gener
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*GenericConfigurationParameterElement), nil
@@ -159,7 +210,14 @@ func (lds *Ldsv3) GetLogConfigurationParameter(ID, parameterType string) (*Gener
 
 // GetDeliveryFrequency returns a specific delivery frequency.
 func (lds *Ldsv3) GetDeliveryFrequency(deliveryFrequencyID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(deliveryFrequencyID, "delivery-frequencies")
+	return lds.GetDeliveryFrequencyWithContext(context.Background(), deliveryFrequencyID)
+}
+
+// GetDeliveryFrequencyWithContext is the same as GetDeliveryFrequency with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetDeliveryFrequencyWithContext(ctx context.Context, deliveryFrequencyID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetDeliveryFrequency")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, deliveryFrequencyID, "delivery-frequencies")
 
 	if err != nil {
 		return nil, err
@@ -170,7 +228,14 @@ func (lds *Ldsv3) GetDeliveryFrequency(deliveryFrequencyID string) (*GenericConf
 
 // GetDeliveryThreshold returns a specific delivery frequency.
 func (lds *Ldsv3) GetDeliveryThreshold(deliveryThresholdID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(deliveryThresholdID, "delivery-thresholds")
+	return lds.GetDeliveryThresholdWithContext(context.Background(), deliveryThresholdID)
+}
+
+// GetDeliveryThresholdWithContext is the same as GetDeliveryThreshold with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetDeliveryThresholdWithContext(ctx context.Context, deliveryThresholdID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetDeliveryThreshold")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, deliveryThresholdID, "delivery-thresholds")
 
 	if err != nil {
 		return nil, err
@@ -182,7 +247,14 @@ func (lds *Ldsv3) GetDeliveryThreshold(deliveryThresholdID string) (*GenericConf
 // GetLogFormat returns a specific log format.
 // You need this id to specify the log format for a log delivery configuration.
 func (lds *Ldsv3) GetLogFormat(logFormatID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(logFormatID, "log-formats")
+	return lds.GetLogFormatWithContext(context.Background(), logFormatID)
+}
+
+// GetLogFormatWithContext is the same as GetLogFormat with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogFormatWithContext(ctx context.Context, logFormatID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogFormat")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, logFormatID, "log-formats")
 
 	if err != nil {
 		return nil, err
@@ -193,7 +265,14 @@ func (lds *Ldsv3) GetLogFormat(logFormatID string) (*GenericConfigurationParamet
 
 // GetLogEncoding returns a specific log encoding.
 func (lds *Ldsv3) GetLogEncoding(encodingID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(encodingID, "encodings")
+	return lds.GetLogEncodingWithContext(context.Background(), encodingID)
+}
+
+// GetLogEncodingWithContext is the same as GetLogEncoding with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogEncodingWithContext(ctx context.Context, encodingID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogEncoding")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, encodingID, "encodings")
 
 	if err != nil {
 		return nil, err
@@ -204,7 +283,14 @@ func (lds *Ldsv3) GetLogEncoding(encodingID string) (*GenericConfigurationParame
 
 // GetMessageSize retrieves a specific message size.
 func (lds *Ldsv3) GetMessageSize(messageSizeID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(messageSizeID, "message-sizes")
+	return lds.GetMessageSizeWithContext(context.Background(), messageSizeID)
+}
+
+// GetMessageSizeWithContext is the same as GetMessageSize with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetMessageSizeWithContext(ctx context.Context, messageSizeID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetMessageSize")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, messageSizeID, "message-sizes")
 
 	if err != nil {
 		return nil, err
@@ -215,7 +301,14 @@ func (lds *Ldsv3) GetMessageSize(messageSizeID string) (*GenericConfigurationPar
 
 // GetContact returns a specific contact, assuming the identity associated with the API client has access to it.
 func (lds *Ldsv3) GetContact(contactID string) (*GenericConfigurationParameterElement, error) {
-	resp, err := lds.GetLogConfigurationParameter(contactID, "contacts")
+	return lds.GetContactWithContext(context.Background(), contactID)
+}
+
+// GetContactWithContext is the same as GetContact with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetContactWithContext(ctx context.Context, contactID string) (*GenericConfigurationParameterElement, error) {
+	ctx = client.WithOperation(ctx, "GetContact")
+	resp, err := lds.GetLogConfigurationParameterWithContext(ctx, contactID, "contacts")
 
 	if err != nil {
 		return nil, err
@@ -1,13 +1,23 @@
 package ldsv3
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // GetLogConfiguration retrieves a specific log delivery configuration.
 func (lds *Ldsv3) GetLogConfiguration(logConfigurationID string) (*OutputConfigurationElement, error) {
+	return lds.GetLogConfigurationWithContext(context.Background(), logConfigurationID)
+}
+
+// GetLogConfigurationWithContext is the same as GetLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogConfigurationWithContext(ctx context.Context, logConfigurationID string) (*OutputConfigurationElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogConfiguration")
 	if logConfigurationID == "" {
 		return nil, fmt.Errorf("Please provide log configuration ID")
 	}
@@ -16,6 +26,7 @@ func (lds *Ldsv3) GetLogConfiguration(logConfigurationID string) (*OutputConfigu
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputConfigurationElement{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -25,9 +36,7 @@ func (lds *Ldsv3) GetLogConfiguration(logConfigurationID string) (*OutputConfigu
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputConfigurationElement), nil
@@ -38,6 +47,13 @@ func (lds *Ldsv3) GetLogConfiguration(logConfigurationID string) (*OutputConfigu
 // The response’s Location header reflects where you can access the new configuration.
 // You have to have top group account permissions for this call
 func (lds *Ldsv3) UpdateLogConfiguration(logConfigurationID string, body ConfigurationBody) (string, error) {
+	return lds.UpdateLogConfigurationWithContext(context.Background(), logConfigurationID, body)
+}
+
+// UpdateLogConfigurationWithContext is the same as UpdateLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) UpdateLogConfigurationWithContext(ctx context.Context, logConfigurationID string, body ConfigurationBody) (string, error) {
+	ctx = client.WithOperation(ctx, "UpdateLogConfiguration")
 	if logConfigurationID == "" {
 		return "", fmt.Errorf("Please provide log configuration ID")
 	}
@@ -46,6 +62,7 @@ func (lds *Ldsv3) UpdateLogConfiguration(logConfigurationID string, body Configu
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		SetHeader("Content-Type", "application/json").
 		SetBody(body).
@@ -57,9 +74,7 @@ func (lds *Ldsv3) UpdateLogConfiguration(logConfigurationID string, body Configu
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return "", e
+		return "", client.NewError(resp)
 	}
 
 	headers := resp.Header()
@@ -76,6 +91,13 @@ func (lds *Ldsv3) UpdateLogConfiguration(logConfigurationID string, body Configu
 
 // RemoveLogConfiguration deletes a specific log delivery configuration.
 func (lds *Ldsv3) RemoveLogConfiguration(logConfigurationID string) error {
+	return lds.RemoveLogConfigurationWithContext(context.Background(), logConfigurationID)
+}
+
+// RemoveLogConfigurationWithContext is the same as RemoveLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) RemoveLogConfigurationWithContext(ctx context.Context, logConfigurationID string) error {
+	ctx = client.WithOperation(ctx, "RemoveLogConfiguration")
 	if logConfigurationID == "" {
 		return fmt.Errorf("Please provide log configuration ID")
 	}
@@ -84,6 +106,7 @@ func (lds *Ldsv3) RemoveLogConfiguration(logConfigurationID string) error {
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		Delete(apiURI)
 
@@ -92,9 +115,7 @@ func (lds *Ldsv3) RemoveLogConfiguration(logConfigurationID string) error {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return e
+		return client.NewError(resp)
 	}
 
 	return nil
@@ -103,6 +124,13 @@ func (lds *Ldsv3) RemoveLogConfiguration(logConfigurationID string) error {
 // CopyLogConfiguration copies a specific log delivery configuration to a target log source to produce a new log delivery configuration.
 // You have to have top group account permissions for this call
 func (lds *Ldsv3) CopyLogConfiguration(logConfigurationID string, body ConfigurationCopyBody) (string, error) {
+	return lds.CopyLogConfigurationWithContext(context.Background(), logConfigurationID, body)
+}
+
+// CopyLogConfigurationWithContext is the same as CopyLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) CopyLogConfigurationWithContext(ctx context.Context, logConfigurationID string, body ConfigurationCopyBody) (string, error) {
+	ctx = client.WithOperation(ctx, "CopyLogConfiguration")
 	if logConfigurationID == "" {
 		return "", fmt.Errorf("Please provide log configuration ID")
 	}
@@ -111,6 +139,7 @@ func (lds *Ldsv3) CopyLogConfiguration(logConfigurationID string, body Configura
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		SetHeader("Content-Type", "application/json").
 		SetBody(body).
@@ -121,9 +150,7 @@ func (lds *Ldsv3) CopyLogConfiguration(logConfigurationID string, body Configura
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return "", e
+		return "", client.NewError(resp)
 	}
 
 	headers := resp.Header()
@@ -142,6 +169,13 @@ func (lds *Ldsv3) CopyLogConfiguration(logConfigurationID string, body Configura
 // SuspendLogConfiguration suspends log delivery for a specific configuration.
 // You will not receive logs for this configuration while it is suspended.
 func (lds *Ldsv3) SuspendLogConfiguration(logConfigurationID string) error {
+	return lds.SuspendLogConfigurationWithContext(context.Background(), logConfigurationID)
+}
+
+// SuspendLogConfigurationWithContext is the same as SuspendLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) SuspendLogConfigurationWithContext(ctx context.Context, logConfigurationID string) error {
+	ctx = client.WithOperation(ctx, "SuspendLogConfiguration")
 	if logConfigurationID == "" {
 		return fmt.Errorf("Please provide log configuration ID")
 	}
@@ -150,6 +184,7 @@ func (lds *Ldsv3) SuspendLogConfiguration(logConfigurationID string) error {
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		Post(apiURI)
 
@@ -158,9 +193,7 @@ func (lds *Ldsv3) SuspendLogConfiguration(logConfigurationID string) error {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return e
+		return client.NewError(resp)
 	}
 
 	return nil
@@ -168,6 +201,13 @@ func (lds *Ldsv3) SuspendLogConfiguration(logConfigurationID string) error {
 
 // ResumeLogConfiguration resumes log delivery for a specific configuration.
 func (lds *Ldsv3) ResumeLogConfiguration(logConfigurationID string) error {
+	return lds.ResumeLogConfigurationWithContext(context.Background(), logConfigurationID)
+}
+
+// ResumeLogConfigurationWithContext is the same as ResumeLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ResumeLogConfigurationWithContext(ctx context.Context, logConfigurationID string) error {
+	ctx = client.WithOperation(ctx, "ResumeLogConfiguration")
 	if logConfigurationID == "" {
 		return fmt.Errorf("Please provide log configuration ID")
 	}
@@ -176,6 +216,7 @@ func (lds *Ldsv3) ResumeLogConfiguration(logConfigurationID string) error {
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		Post(apiURI)
 
@@ -184,9 +225,7 @@ func (lds *Ldsv3) ResumeLogConfiguration(logConfigurationID string) error {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return e
+		return client.NewError(resp)
 	}
 
 	return nil
@@ -196,6 +235,13 @@ func (lds *Ldsv3) ResumeLogConfiguration(logConfigurationID string) error {
 // The response’s Location header reflects where you can access the new configuration.
 // You have to have top group account permissions for this call
 func (lds *Ldsv3) CreateLogConfiguration(logCSourceID, logSourceType string, body ConfigurationBody) (string, error) {
+	return lds.CreateLogConfigurationWithContext(context.Background(), logCSourceID, logSourceType, body)
+}
+
+// CreateLogConfigurationWithContext is the same as CreateLogConfiguration with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) CreateLogConfigurationWithContext(ctx context.Context, logCSourceID, logSourceType string, body ConfigurationBody) (string, error) {
+	ctx = client.WithOperation(ctx, "CreateLogConfiguration")
 	if logCSourceID == "" {
 		return "", fmt.Errorf("Please provide log source ID")
 	}
@@ -208,6 +254,7 @@ func (lds *Ldsv3) CreateLogConfiguration(logCSourceID, logSourceType string, bod
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		SetHeader("Content-Type", "application/json").
 		SetBody(body).
@@ -219,9 +266,7 @@ func (lds *Ldsv3) CreateLogConfiguration(logCSourceID, logSourceType string, bod
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return "", e
+		return "", client.NewError(resp)
 	}
 
 	headers := resp.Header()
@@ -1,13 +1,23 @@
 package ldsv3
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // GetLogRedelivery retrieves a specific log redelivery request.
 func (lds *Ldsv3) GetLogRedelivery(redeliveryID string) (*OutputLogRedeliveryElement, error) {
+	return lds.GetLogRedeliveryWithContext(context.Background(), redeliveryID)
+}
+
+// GetLogRedeliveryWithContext is the same as GetLogRedelivery with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogRedeliveryWithContext(ctx context.Context, redeliveryID string) (*OutputLogRedeliveryElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogRedelivery")
 	if redeliveryID == "" {
 		return nil, fmt.Errorf("Please provide log redelivery ID")
 	}
@@ -16,6 +26,7 @@ func (lds *Ldsv3) GetLogRedelivery(redeliveryID string) (*OutputLogRedeliveryEle
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputLogRedeliveryElement{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -25,9 +36,7 @@ func (lds *Ldsv3) GetLogRedelivery(redeliveryID string) (*OutputLogRedeliveryEle
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputLogRedeliveryElement), nil
@@ -35,10 +44,18 @@ func (lds *Ldsv3) GetLogRedelivery(redeliveryID string) (*OutputLogRedeliveryEle
 
 // ListLogRedeliveries retrieves a list of requests to redeliver logs.
 func (lds *Ldsv3) ListLogRedeliveries() (*OutputLogRedelivery, error) {
+	return lds.ListLogRedeliveriesWithContext(context.Background())
+}
+
+// ListLogRedeliveriesWithContext is the same as ListLogRedeliveries with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogRedeliveriesWithContext(ctx context.Context) (*OutputLogRedelivery, error) {
+	ctx = client.WithOperation(ctx, "ListLogRedeliveries")
 	apiURI := fmt.Sprintf("%s/log-redeliveries", basePath)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputLogRedelivery{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -48,20 +65,68 @@ func (lds *Ldsv3) ListLogRedeliveries() (*OutputLogRedelivery, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputLogRedelivery), nil
 }
 
+// LogRedeliveriesPager iterates over log redeliveries, see ListLogRedeliveriesPager.
+type LogRedeliveriesPager struct {
+	*client.Pager
+}
+
+// Value returns current log redelivery.
+func (p *LogRedeliveriesPager) Value() *OutputLogRedeliveryElement {
+	v, _ := p.Pager.Value().(*OutputLogRedeliveryElement)
+	return v
+}
+
+// ListLogRedeliveriesPager returns iterator over all requests to redeliver logs.
+// Next pages are fetched on demand when API paginates results.
+func (lds *Ldsv3) ListLogRedeliveriesPager(ctx context.Context) *LogRedeliveriesPager {
+	ctx = client.WithOperation(ctx, "ListLogRedeliveries")
+	fetch := func(ctx context.Context, token *client.PageToken) ([]interface{}, *client.PageToken, error) {
+		req := lds.Client.Rclient.R().
+			SetContext(ctx).
+			SetResult(OutputLogRedelivery{}).
+			SetError(LsdErrorv3{})
+
+		resp, err := client.GetPage(req, fmt.Sprintf("%s/log-redeliveries", basePath), token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resp.IsError() {
+			return nil, nil, client.NewError(resp)
+		}
+
+		redeliveries := *resp.Result().(*OutputLogRedelivery)
+		items := make([]interface{}, len(redeliveries))
+		for i := range redeliveries {
+			items[i] = &redeliveries[i]
+		}
+
+		return items, client.NextPageToken(resp.Body(), token, len(items)), nil
+	}
+
+	return &LogRedeliveriesPager{client.NewPager(ctx, fetch)}
+}
+
 // CreateLogRedeliveries creates a new request to resend a log.
 func (lds *Ldsv3) CreateLogRedeliveries(body RedeliveryBody) (string, error) {
+	return lds.CreateLogRedeliveriesWithContext(context.Background(), body)
+}
+
+// CreateLogRedeliveriesWithContext is the same as CreateLogRedeliveries with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) CreateLogRedeliveriesWithContext(ctx context.Context, body RedeliveryBody) (string, error) {
+	ctx = client.WithOperation(ctx, "CreateLogRedeliveries")
 	apiURI := fmt.Sprintf("%s/log-redeliveries", basePath)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(LsdErrorv3{}).
 		SetHeader("Content-Type", "application/json").
 		SetBody(body).
@@ -73,9 +138,7 @@ func (lds *Ldsv3) CreateLogRedeliveries(body RedeliveryBody) (string, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return "", e
+		return "", client.NewError(resp)
 	}
 
 	headers := resp.Header()
@@ -1,26 +1,38 @@
 package ldsv3
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
 
 // ListLogEncodingsByType retrieves all allowable log encodings.
 func (lds *Ldsv3) ListLogEncodingsByType(logSourceType, deliveryType string) (*ConfigurationParameterResponse, error) {
+	return lds.ListLogEncodingsByTypeWithContext(context.Background(), logSourceType, deliveryType)
+}
+
+// ListLogEncodingsByTypeWithContext is the same as ListLogEncodingsByType with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogEncodingsByTypeWithContext(ctx context.Context, logSourceType, deliveryType string) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListLogEncodingsByType")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
 
 	apiURI := fmt.Sprintf("%s/log-sources/%s/encodings", basePath, logSourceType)
 
-	query := map[string]string{}
-
-	if deliveryType != "" {
-		query["deliveryType"] = deliveryType
+	query, err := client.QueryParams(logEncodingsOptions{DeliveryType: deliveryType})
+	if err != nil {
+		return nil, err
 	}
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(ConfigurationParameterResponse{}).
 		SetError(LsdErrorv3{}).
-		SetQueryParams(query).
+		SetQueryParamsFromValues(query).
 		Get(apiURI)
 
 	if err != nil {
@@ -28,9 +40,7 @@ func (lds *Ldsv3) ListLogEncodingsByType(logSourceType, deliveryType string) (*C
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*ConfigurationParameterResponse), nil
@@ -38,6 +48,13 @@ func (lds *Ldsv3) ListLogEncodingsByType(logSourceType, deliveryType string) (*C
 
 // ListLogFormatPerID gets log formats of given logSourceType and logSourceId.
 func (lds *Ldsv3) ListLogFormatPerID(logSourceID, logSourceType string) (*ConfigurationParameterResponse, error) {
+	return lds.ListLogFormatPerIDWithContext(context.Background(), logSourceID, logSourceType)
+}
+
+// ListLogFormatPerIDWithContext is the same as ListLogFormatPerID with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogFormatPerIDWithContext(ctx context.Context, logSourceID, logSourceType string) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListLogFormatPerID")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
@@ -50,6 +67,7 @@ func (lds *Ldsv3) ListLogFormatPerID(logSourceID, logSourceType string) (*Config
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(ConfigurationParameterResponse{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -59,9 +77,7 @@ func (lds *Ldsv3) ListLogFormatPerID(logSourceID, logSourceType string) (*Config
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*ConfigurationParameterResponse), nil
@@ -70,6 +86,13 @@ func (lds *Ldsv3) ListLogFormatPerID(logSourceID, logSourceType string) (*Config
 // ListLogFormatByType returns all available log formats for the specified logSourceType type.
 // You need the Id of log format to create new log delivery configurations.
 func (lds *Ldsv3) ListLogFormatByType(logSourceType string) (*ConfigurationParameterResponse, error) {
+	return lds.ListLogFormatByTypeWithContext(context.Background(), logSourceType)
+}
+
+// ListLogFormatByTypeWithContext is the same as ListLogFormatByType with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogFormatByTypeWithContext(ctx context.Context, logSourceType string) (*ConfigurationParameterResponse, error) {
+	ctx = client.WithOperation(ctx, "ListLogFormatByType")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
@@ -78,6 +101,7 @@ func (lds *Ldsv3) ListLogFormatByType(logSourceType string) (*ConfigurationParam
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(ConfigurationParameterResponse{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -87,9 +111,7 @@ func (lds *Ldsv3) ListLogFormatByType(logSourceType string) (*ConfigurationParam
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*ConfigurationParameterResponse), nil
@@ -98,6 +120,13 @@ func (lds *Ldsv3) ListLogFormatByType(logSourceType string) (*ConfigurationParam
 // ListLogConfigurationsByType returns all log delivery configurations of a given logSourceType.
 // You would need the logConfigurationId to modify a log delivery configuration.
 func (lds *Ldsv3) ListLogConfigurationsByType(logSourceType string) (*OutputConfigurations, error) {
+	return lds.ListLogConfigurationsByTypeWithContext(context.Background(), logSourceType)
+}
+
+// ListLogConfigurationsByTypeWithContext is the same as ListLogConfigurationsByType with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogConfigurationsByTypeWithContext(ctx context.Context, logSourceType string) (*OutputConfigurations, error) {
+	ctx = client.WithOperation(ctx, "ListLogConfigurationsByType")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
@@ -106,6 +135,7 @@ func (lds *Ldsv3) ListLogConfigurationsByType(logSourceType string) (*OutputConf
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputConfigurations{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -115,9 +145,7 @@ func (lds *Ldsv3) ListLogConfigurationsByType(logSourceType string) (*OutputConf
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputConfigurations), nil
@@ -125,6 +153,13 @@ func (lds *Ldsv3) ListLogConfigurationsByType(logSourceType string) (*OutputConf
 
 // ListLogConfigurationsPerID gets all log configurations of given logSourceType and logSourceId.
 func (lds *Ldsv3) ListLogConfigurationsPerID(logSourceID, logSourceType string) (*OutputConfigurations, error) {
+	return lds.ListLogConfigurationsPerIDWithContext(context.Background(), logSourceID, logSourceType)
+}
+
+// ListLogConfigurationsPerIDWithContext is the same as ListLogConfigurationsPerID with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListLogConfigurationsPerIDWithContext(ctx context.Context, logSourceID, logSourceType string) (*OutputConfigurations, error) {
+	ctx = client.WithOperation(ctx, "ListLogConfigurationsPerID")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
@@ -137,6 +172,7 @@ func (lds *Ldsv3) ListLogConfigurationsPerID(logSourceID, logSourceType string)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputConfigurations{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -146,9 +182,7 @@ func (lds *Ldsv3) ListLogConfigurationsPerID(logSourceID, logSourceType string)
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputConfigurations), nil
@@ -157,10 +191,18 @@ func (lds *Ldsv3) ListLogConfigurationsPerID(logSourceID, logSourceType string)
 // ListSources returns all log sources (logSourceType) and log source ID (logSourceId) to which the user has access.
 // You need the logSourceType and logSourceId to create a log delivery configuration.
 func (lds *Ldsv3) ListSources() (*OutputSources, error) {
+	return lds.ListSourcesWithContext(context.Background())
+}
+
+// ListSourcesWithContext is the same as ListSources with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListSourcesWithContext(ctx context.Context) (*OutputSources, error) {
+	ctx = client.WithOperation(ctx, "ListSources")
 	apiURI := fmt.Sprintf("%s/log-sources", basePath)
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputSources{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -170,9 +212,7 @@ func (lds *Ldsv3) ListSources() (*OutputSources, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputSources), nil
@@ -181,6 +221,13 @@ func (lds *Ldsv3) ListSources() (*OutputSources, error) {
 // ListSourcesByType returns all log sources of the specified logSourceType,
 // one of cpcode-products, gtm-properties, edns-zones, or answerx-objects.
 func (lds *Ldsv3) ListSourcesByType(logSourceType string) (*OutputSources, error) {
+	return lds.ListSourcesByTypeWithContext(context.Background(), logSourceType)
+}
+
+// ListSourcesByTypeWithContext is the same as ListSourcesByType with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) ListSourcesByTypeWithContext(ctx context.Context, logSourceType string) (*OutputSources, error) {
+	ctx = client.WithOperation(ctx, "ListSourcesByType")
 	if logSourceType == "" {
 		return nil, fmt.Errorf("Missing argument 'logSourceType'. Most probably you need cpcode-products as argument")
 	}
@@ -189,6 +236,7 @@ func (lds *Ldsv3) ListSourcesByType(logSourceType string) (*OutputSources, error
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputSources{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -198,9 +246,7 @@ func (lds *Ldsv3) ListSourcesByType(logSourceType string) (*OutputSources, error
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputSources), nil
@@ -208,6 +254,13 @@ func (lds *Ldsv3) ListSourcesByType(logSourceType string) (*OutputSources, error
 
 // GetLogSource gets a log source of a given logSourceType type and logSourceId.
 func (lds *Ldsv3) GetLogSource(logSourceID, logSourceType string) (*OutputSourcesElement, error) {
+	return lds.GetLogSourceWithContext(context.Background(), logSourceID, logSourceType)
+}
+
+// GetLogSourceWithContext is the same as GetLogSource with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (lds *Ldsv3) GetLogSourceWithContext(ctx context.Context, logSourceID, logSourceType string) (*OutputSourcesElement, error) {
+	ctx = client.WithOperation(ctx, "GetLogSource")
 	if logSourceID == "" {
 		return nil, fmt.Errorf("Please provide log source ID")
 	}
@@ -220,6 +273,7 @@ func (lds *Ldsv3) GetLogSource(logSourceID, logSourceType string) (*OutputSource
 
 	// Create and execute request
 	resp, err := lds.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(OutputSourcesElement{}).
 		SetError(LsdErrorv3{}).
 		Get(apiURI)
@@ -229,9 +283,7 @@ func (lds *Ldsv3) GetLogSource(logSourceID, logSourceType string) (*OutputSource
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*LsdErrorv3)
-
-		return nil, e
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*OutputSourcesElement), nil
@@ -227,3 +227,9 @@ type RedeliveryBody struct {
 	// Date from which log redelivery is requested.
 	RedeliveryDate string `json:"redeliveryDate"`
 }
+
+// logEncodingsOptions is query of ListLogEncodings and ListLogEncodingsByType
+type logEncodingsOptions struct {
+	DeliveryType  string `url:"deliveryType,omitempty"`
+	LogSourceType string `url:"logSourceType,omitempty"`
+}
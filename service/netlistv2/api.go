@@ -1,16 +1,30 @@
 package netlistv2
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 // ModifyNetworkList Modify an existing network list
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postlists
 func (nls *Netlistv2) ModifyNetworkList(mod NetworkListv2) (*NetworkListv2, error) {
+	return nls.ModifyNetworkListWithContext(context.Background(), mod)
+}
+
+// ModifyNetworkListWithContext is the same as ModifyNetworkList with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) ModifyNetworkListWithContext(ctx context.Context, mod NetworkListv2) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "ModifyNetworkList")
+
+	if err := ValidateElements(mod.Type, mod.List); err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListv2{}).
 		SetError(NetworkListErrorv2{}).
 		SetBody(mod).
@@ -21,10 +35,7 @@ func (nls *Netlistv2) ModifyNetworkList(mod NetworkListv2) (*NetworkListv2, erro
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
@@ -33,14 +44,23 @@ func (nls *Netlistv2) ModifyNetworkList(mod NetworkListv2) (*NetworkListv2, erro
 // ListNetworkLists List all configured Network Lists for the authenticated user.
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getlists
 func (nls *Netlistv2) ListNetworkLists(opts ListNetworkListsOptionsv2) (*NetworkListsv2, error) {
+	return nls.ListNetworkListsWithContext(context.Background(), opts)
+}
+
+// ListNetworkListsWithContext is the same as ListNetworkLists with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) ListNetworkListsWithContext(ctx context.Context, opts ListNetworkListsOptionsv2) (*NetworkListsv2, error) {
+	ctx = client.WithOperation(ctx, "ListNetworkLists")
+
+	query, err := client.QueryParams(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
-		SetQueryParams(map[string]string{
-			"extended":        strconv.FormatBool(opts.Extended),
-			"includeElements": strconv.FormatBool(opts.IncludeElements),
-			"search":          opts.Search,
-		}).
+		SetContext(ctx).
+		SetQueryParamsFromValues(query).
 		SetResult(NetworkListsv2{}).
 		SetError(NetworkListErrorv2{}).
 		Get(basePath)
@@ -50,21 +70,78 @@ func (nls *Netlistv2) ListNetworkLists(opts ListNetworkListsOptionsv2) (*Network
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListsv2), nil
 }
 
+// NetworkListsPager iterates over network lists, see ListNetworkListsPager.
+type NetworkListsPager struct {
+	*client.Pager
+}
+
+// Value returns current network list.
+func (p *NetworkListsPager) Value() *NetworkListv2 {
+	v, _ := p.Pager.Value().(*NetworkListv2)
+	return v
+}
+
+// ListNetworkListsPager returns iterator over all configured Network Lists.
+// Next pages are fetched on demand when API paginates results.
+func (nls *Netlistv2) ListNetworkListsPager(ctx context.Context, opts ListNetworkListsOptionsv2) *NetworkListsPager {
+	ctx = client.WithOperation(ctx, "ListNetworkLists")
+	query, queryErr := client.QueryParams(opts)
+	fetch := func(ctx context.Context, token *client.PageToken) ([]interface{}, *client.PageToken, error) {
+		if queryErr != nil {
+			return nil, nil, queryErr
+		}
+
+		req := nls.Client.Rclient.R().
+			SetContext(ctx).
+			SetQueryParamsFromValues(query).
+			SetResult(NetworkListsv2{}).
+			SetError(NetworkListErrorv2{})
+
+		resp, err := client.GetPage(req, basePath, token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resp.IsError() {
+			return nil, nil, client.NewError(resp)
+		}
+
+		lists := resp.Result().(*NetworkListsv2).NetworkLists
+		items := make([]interface{}, len(lists))
+		for i := range lists {
+			items[i] = &lists[i]
+		}
+
+		return items, client.NextPageToken(resp.Body(), token, len(items)), nil
+	}
+
+	return &NetworkListsPager{client.NewPager(ctx, fetch)}
+}
+
 // CreateNetworkList Create a new network list
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postlists
 func (nls *Netlistv2) CreateNetworkList(opts NetworkListsOptionsv2) (*NetworkListv2, error) {
+	return nls.CreateNetworkListWithContext(context.Background(), opts)
+}
+
+// CreateNetworkListWithContext is the same as CreateNetworkList with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) CreateNetworkListWithContext(ctx context.Context, opts NetworkListsOptionsv2) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "CreateNetworkList")
+
+	if err := ValidateElements(opts.Type, opts.List); err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListv2{}).
 		SetError(NetworkListErrorv2{}).
 		SetBody(opts).
@@ -75,10 +152,7 @@ func (nls *Netlistv2) CreateNetworkList(opts NetworkListsOptionsv2) (*NetworkLis
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
@@ -87,13 +161,23 @@ func (nls *Netlistv2) CreateNetworkList(opts NetworkListsOptionsv2) (*NetworkLis
 // GetNetworkList Gets a specific network list
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getlist
 func (nls *Netlistv2) GetNetworkList(ListID string, opts ListNetworkListsOptionsv2) (*NetworkListv2, error) {
+	return nls.GetNetworkListWithContext(context.Background(), ListID, opts)
+}
+
+// GetNetworkListWithContext is the same as GetNetworkList with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) GetNetworkListWithContext(ctx context.Context, ListID string, opts ListNetworkListsOptionsv2) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "GetNetworkList")
+
+	query, err := client.QueryParams(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
-		SetQueryParams(map[string]string{
-			"extended":        strconv.FormatBool(opts.Extended),
-			"includeElements": strconv.FormatBool(opts.IncludeElements),
-		}).
+		SetContext(ctx).
+		SetQueryParamsFromValues(query).
 		SetResult(NetworkListv2{}).
 		SetError(NetworkListErrorv2{}).
 		Get(fmt.Sprintf("%s/%s", basePath, ListID))
@@ -103,22 +187,77 @@ func (nls *Netlistv2) GetNetworkList(ListID string, opts ListNetworkListsOptions
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
 
 }
 
+// NetworkListElementsPager iterates over elements of network list, see GetNetworkListElementsPager.
+type NetworkListElementsPager struct {
+	*client.Pager
+}
+
+// Value returns current element.
+func (p *NetworkListElementsPager) Value() string {
+	v, _ := p.Pager.Value().(string)
+	return v
+}
+
+// GetNetworkListElementsPager returns iterator over elements of very large network list.
+// Elements are fetched on demand in pages of pageSize elements.
+func (nls *Netlistv2) GetNetworkListElementsPager(ctx context.Context, ListID string, pageSize int) *NetworkListElementsPager {
+	ctx = client.WithOperation(ctx, "GetNetworkList")
+	fetch := func(ctx context.Context, token *client.PageToken) ([]interface{}, *client.PageToken, error) {
+		opts := ListNetworkListsOptionsv2{
+			IncludeElements: true,
+			ElementsLimit:   pageSize,
+		}
+		if token != nil {
+			opts.ElementsOffset = token.Offset
+		}
+
+		list, err := nls.GetNetworkListWithContext(ctx, ListID, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(list.List))
+		for i, e := range list.List {
+			items[i] = e
+		}
+
+		// API which returns all elements at once reports count equal to received elements
+		next := opts.ElementsOffset + len(items)
+		if len(items) == 0 || next >= list.ElementCount {
+			return items, nil, nil
+		}
+
+		return items, &client.PageToken{Offset: next}, nil
+	}
+
+	return &NetworkListElementsPager{client.NewPager(ctx, fetch)}
+}
+
 // AddNetworkListElement Adds items to network list
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postlists
 func (nls *Netlistv2) AddNetworkListElement(ListID string, opts NetworkListsOptionsv2) (*NetworkListv2, error) {
+	return nls.AddNetworkListElementWithContext(context.Background(), ListID, opts)
+}
+
+// AddNetworkListElementWithContext is the same as AddNetworkListElement with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) AddNetworkListElementWithContext(ctx context.Context, ListID string, opts NetworkListsOptionsv2) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "AddNetworkListElement")
+
+	if err := ValidateElements(opts.Type, opts.List); err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListv2{}).
 		SetError(NetworkListErrorv2{}).
 		SetBody(opts).
@@ -129,10 +268,7 @@ func (nls *Netlistv2) AddNetworkListElement(ListID string, opts NetworkListsOpti
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
@@ -141,13 +277,24 @@ func (nls *Netlistv2) AddNetworkListElement(ListID string, opts NetworkListsOpti
 // RemoveNetworkListElement Removes network list element
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) RemoveNetworkListElement(ListID, element string) (*NetworkListv2, error) {
+	return nls.RemoveNetworkListElementWithContext(context.Background(), ListID, element)
+}
+
+// RemoveNetworkListElementWithContext is the same as RemoveNetworkListElement with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) RemoveNetworkListElementWithContext(ctx context.Context, ListID, element string) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "RemoveNetworkListElement")
+
+	query, err := client.QueryParams(removeElementOptions{Element: element})
+	if err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListv2{}).
-		SetQueryParams(map[string]string{
-			"element": element,
-		}).
+		SetQueryParamsFromValues(query).
 		SetError(NetworkListErrorv2{}).
 		Delete(fmt.Sprintf("%s/%s/elements", basePath, ListID))
 
@@ -156,10 +303,7 @@ func (nls *Netlistv2) RemoveNetworkListElement(ListID, element string) (*Network
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
@@ -168,9 +312,17 @@ func (nls *Netlistv2) RemoveNetworkListElement(ListID, element string) (*Network
 // ActivateNetworkList Activates network list on specified network ( PRODUCTION or STAGING )
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) ActivateNetworkList(ListID string, targetEnv AkamaiEnvironment, opts NetworkListActivationOptsv2) (*NetworkListActivationStatusv2, error) {
+	return nls.ActivateNetworkListWithContext(context.Background(), ListID, targetEnv, opts)
+}
+
+// ActivateNetworkListWithContext is the same as ActivateNetworkList with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) ActivateNetworkListWithContext(ctx context.Context, ListID string, targetEnv AkamaiEnvironment, opts NetworkListActivationOptsv2) (*NetworkListActivationStatusv2, error) {
+	ctx = client.WithOperation(ctx, "ActivateNetworkList")
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetBody(opts).
 		SetResult(NetworkListActivationStatusv2{}).
 		SetError(NetworkListErrorv2{}).
@@ -181,10 +333,7 @@ func (nls *Netlistv2) ActivateNetworkList(ListID string, targetEnv AkamaiEnviron
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListActivationStatusv2), nil
@@ -193,9 +342,17 @@ func (nls *Netlistv2) ActivateNetworkList(ListID string, targetEnv AkamaiEnviron
 // GetActivationStatus Gets activation network list status on specified network ( PRODUCTION or STAGING )
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) GetActivationStatus(ListID string, targetEnv AkamaiEnvironment) (*NetworkListActivationStatusv2, error) {
+	return nls.GetActivationStatusWithContext(context.Background(), ListID, targetEnv)
+}
+
+// GetActivationStatusWithContext is the same as GetActivationStatus with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) GetActivationStatusWithContext(ctx context.Context, ListID string, targetEnv AkamaiEnvironment) (*NetworkListActivationStatusv2, error) {
+	ctx = client.WithOperation(ctx, "GetActivationStatus")
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListActivationStatusv2{}).
 		SetError(NetworkListErrorv2{}).
 		Get(fmt.Sprintf("%s/%s/environments/%s/status", basePath, ListID, targetEnv))
@@ -205,10 +362,7 @@ func (nls *Netlistv2) GetActivationStatus(ListID string, targetEnv AkamaiEnviron
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListActivationStatusv2), nil
@@ -218,9 +372,17 @@ func (nls *Netlistv2) GetActivationStatus(ListID string, targetEnv AkamaiEnviron
 // DeleteNetworkList Remove network list
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) DeleteNetworkList(ListID string) (*NetworkListDeleteResponse, error) {
+	return nls.DeleteNetworkListWithContext(context.Background(), ListID)
+}
+
+// DeleteNetworkListWithContext is the same as DeleteNetworkList with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) DeleteNetworkListWithContext(ctx context.Context, ListID string) (*NetworkListDeleteResponse, error) {
+	ctx = client.WithOperation(ctx, "DeleteNetworkList")
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListDeleteResponse{}).
 		SetError(NetworkListErrorv2{}).
 		Delete(fmt.Sprintf("%s/%s", basePath, ListID))
@@ -230,10 +392,7 @@ func (nls *Netlistv2) DeleteNetworkList(ListID string) (*NetworkListDeleteRespon
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListDeleteResponse), nil
@@ -242,13 +401,17 @@ func (nls *Netlistv2) DeleteNetworkList(ListID string) (*NetworkListDeleteRespon
 // NetworkListNotification Manage network list subscription
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) NetworkListNotification(action AkamaiSubscription, sub NetworkListSubscription) error {
+	return nls.NetworkListNotificationWithContext(context.Background(), action, sub)
+}
 
-	var networkListv2 NetworkListv2
-	var e NetworkListErrorv2
+// NetworkListNotificationWithContext is the same as NetworkListNotification with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) NetworkListNotificationWithContext(ctx context.Context, action AkamaiSubscription, sub NetworkListSubscription) error {
+	ctx = client.WithOperation(ctx, "NetworkListNotification")
 
 	// Create and execute request
-	_, err := nls.Client.Rclient.R().
-		SetResult(&networkListv2).
+	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetError(NetworkListErrorv2{}).
 		SetBody(sub).
 		Post(fmt.Sprintf("/network-list/v2/notifications/%s", action))
@@ -257,24 +420,34 @@ func (nls *Netlistv2) NetworkListNotification(action AkamaiSubscription, sub Net
 		return err
 	}
 
-	if e.Status != 0 {
-		return e
+	if resp.IsError() {
+		return client.NewError(resp)
 	}
 
 	return nil
-
 }
 
 // GetActivationSnapshot Gets state of network list for a specific sync point
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 func (nls *Netlistv2) GetActivationSnapshot(ListID string, syncPoint int, extended bool) (*NetworkListv2, error) {
+	return nls.GetActivationSnapshotWithContext(context.Background(), ListID, syncPoint, extended)
+}
+
+// GetActivationSnapshotWithContext is the same as GetActivationSnapshot with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (nls *Netlistv2) GetActivationSnapshotWithContext(ctx context.Context, ListID string, syncPoint int, extended bool) (*NetworkListv2, error) {
+	ctx = client.WithOperation(ctx, "GetActivationSnapshot")
+
+	query, err := client.QueryParams(activationSnapshotOptions{Extended: extended})
+	if err != nil {
+		return nil, err
+	}
 
 	// Create and execute request
 	resp, err := nls.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(NetworkListv2{}).
-		SetQueryParams(map[string]string{
-			"extended": strconv.FormatBool(extended),
-		}).
+		SetQueryParamsFromValues(query).
 		SetError(NetworkListErrorv2{}).
 		Get(fmt.Sprintf("%s/%s/sync-points/%d/history", basePath, ListID, syncPoint))
 
@@ -283,10 +456,7 @@ func (nls *Netlistv2) GetActivationSnapshot(ListID string, syncPoint int, extend
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*NetworkListErrorv2)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*NetworkListv2), nil
@@ -0,0 +1,364 @@
+package netlistv2
+
+import (
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Types of network lists
+const (
+	TypeIP  = "IP"
+	TypeGEO = "GEO"
+)
+
+// isoCountries lists ISO 3166-1 alpha-2 country codes
+const isoCountries = "AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+	"CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+	"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT " +
+	"MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+	"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ " +
+	"UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+var (
+	countries = map[string]struct{}{}
+
+	// subdivisionPattern matches ISO 3166-2 subdivision i.e. `US-CA`
+	subdivisionPattern = regexp.MustCompile(`^([A-Z]{2})-[A-Z0-9]{1,3}$`)
+)
+
+func init() {
+	for _, c := range strings.Fields(isoCountries) {
+		countries[c] = struct{}{}
+	}
+}
+
+// Element is network list element parsed according to list type. IP lists hold
+// prefixes ( single addresses are host prefixes ), GEO lists ISO country codes.
+type Element struct {
+	Prefix  netip.Prefix
+	Country string
+}
+
+// IsPrefix reports whether element is IP address or CIDR block.
+func (e Element) IsPrefix() bool {
+	return e.Prefix.IsValid()
+}
+
+// String returns canonical form of the element. Host prefixes are
+// written as plain address i.e. `192.0.2.1` instead of `192.0.2.1/32`.
+func (e Element) String() string {
+	if !e.IsPrefix() {
+		return e.Country
+	}
+
+	if e.Prefix.IsSingleIP() {
+		return e.Prefix.Addr().String()
+	}
+
+	return e.Prefix.String()
+}
+
+// ParseElement parses network list element according to list type. For lists
+// of unknown type element is parsed as IP address or CIDR block first.
+func ParseElement(listType, element string) (Element, error) {
+	element = strings.TrimSpace(element)
+
+	switch strings.ToUpper(listType) {
+	case TypeIP:
+		return parsePrefix(element)
+	case TypeGEO:
+		return parseCountry(element)
+	}
+
+	if e, err := parsePrefix(element); err == nil {
+		return e, nil
+	}
+	return parseCountry(element)
+}
+
+// parsePrefix parses IPv4/IPv6 address or CIDR block, host bits are cleared
+func parsePrefix(element string) (Element, error) {
+	if strings.Contains(element, "/") {
+		p, err := netip.ParsePrefix(element)
+		if err != nil {
+			return Element{}, InvalidElement{Element: element, Reason: "invalid CIDR block"}
+		}
+
+		addr, bits := p.Addr(), p.Bits()
+		if addr.Is4In6() && bits >= 96 {
+			addr, bits = addr.Unmap(), bits-96
+		}
+
+		return Element{Prefix: netip.PrefixFrom(addr.WithZone(""), bits).Masked()}, nil
+	}
+
+	addr, err := netip.ParseAddr(element)
+	if err != nil {
+		return Element{}, InvalidElement{Element: element, Reason: "invalid IP address"}
+	}
+
+	addr = addr.Unmap().WithZone("")
+	return Element{Prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+}
+
+// parseCountry parses ISO 3166-1 country code or ISO 3166-2 subdivision
+func parseCountry(element string) (Element, error) {
+	code := strings.ToUpper(element)
+
+	country := code
+	if m := subdivisionPattern.FindStringSubmatch(code); m != nil {
+		country = m[1]
+	}
+
+	if _, ok := countries[country]; !ok {
+		return Element{}, InvalidElement{Element: element, Reason: "invalid country code"}
+	}
+
+	return Element{Country: code}, nil
+}
+
+// canonicalElement returns element in canonical form, elements which
+// cannot be parsed are returned unchanged
+func canonicalElement(listType, element string) string {
+	e, err := ParseElement(listType, element)
+	if err != nil {
+		return element
+	}
+	return e.String()
+}
+
+// ParseElements parses all elements. Error lists every invalid element.
+func ParseElements(listType string, elements []string) ([]Element, error) {
+	parsed := make([]Element, 0, len(elements))
+
+	var invalid []InvalidElement
+	for _, element := range elements {
+		e, err := ParseElement(listType, element)
+		if err != nil {
+			invalid = append(invalid, err.(InvalidElement))
+			continue
+		}
+		parsed = append(parsed, e)
+	}
+
+	if len(invalid) > 0 {
+		return parsed, errInvalidElements(invalid)
+	}
+
+	return parsed, nil
+}
+
+// ValidateElements checks that all elements are valid for the list type.
+func ValidateElements(listType string, elements []string) error {
+	_, err := ParseElements(listType, elements)
+	return err
+}
+
+// NormalizeElements returns canonical, sorted elements without duplicates.
+// Overlapping and adjacent CIDR blocks of IP lists are collapsed.
+func NormalizeElements(listType string, elements []string) ([]string, error) {
+	parsed, err := ParseElements(listType, elements)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []netip.Prefix
+	seen := map[string]struct{}{}
+
+	var normalized []string
+	for _, e := range parsed {
+		if e.IsPrefix() {
+			prefixes = append(prefixes, e.Prefix)
+			continue
+		}
+
+		if _, ok := seen[e.Country]; !ok {
+			seen[e.Country] = struct{}{}
+			normalized = append(normalized, e.Country)
+		}
+	}
+	sort.Strings(normalized)
+
+	for _, p := range CollapsePrefixes(prefixes) {
+		normalized = append(normalized, Element{Prefix: p}.String())
+	}
+
+	return normalized, nil
+}
+
+// CollapsePrefixes merges overlapping and adjacent prefixes into the smallest
+// set of prefixes covering the same addresses. IPv4 prefixes are returned first.
+func CollapsePrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	ranges := make([]addrRange, 0, len(prefixes))
+	for _, p := range prefixes {
+		if p.IsValid() {
+			p = p.Masked()
+			ranges = append(ranges, addrRange{p.Addr(), lastAddr(p)})
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].from.Less(ranges[j].from)
+	})
+
+	var merged []addrRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			next := last.to.Next()
+
+			// Overlapping or adjacent ranges of the same family
+			if last.from.BitLen() == r.from.BitLen() && (!next.IsValid() || !next.Less(r.from)) {
+				if last.to.Less(r.to) {
+					last.to = r.to
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	var collapsed []netip.Prefix
+	for _, r := range merged {
+		collapsed = append(collapsed, r.prefixes()...)
+	}
+
+	return collapsed
+}
+
+// addrRange is inclusive range of addresses of the same family
+type addrRange struct {
+	from, to netip.Addr
+}
+
+// prefixes returns the smallest set of prefixes covering the range
+func (r addrRange) prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+
+	from := r.from
+	for from.IsValid() && !r.to.Less(from) {
+		// Largest block starting at from which does not exceed the range
+		var p netip.Prefix
+		for bits := 0; bits <= from.BitLen(); bits++ {
+			p = netip.PrefixFrom(from, bits).Masked()
+			if p.Addr() == from && !r.to.Less(lastAddr(p)) {
+				break
+			}
+		}
+
+		prefixes = append(prefixes, p)
+		from = lastAddr(p).Next()
+	}
+
+	return prefixes
+}
+
+// lastAddr returns the last address of the prefix
+func lastAddr(p netip.Prefix) netip.Addr {
+	addr := p.Masked().Addr()
+	bits := p.Bits()
+
+	if addr.Is4() {
+		b := addr.As4()
+		for i := range b {
+			b[i] |= hostMask(bits, i)
+		}
+		return netip.AddrFrom4(b)
+	}
+
+	b := addr.As16()
+	for i := range b {
+		b[i] |= hostMask(bits, i)
+	}
+	return netip.AddrFrom16(b)
+}
+
+// hostMask returns host bits of i-th byte of address with prefix of given length
+func hostMask(bits, i int) byte {
+	switch {
+	case bits >= (i+1)*8:
+		return 0
+	case bits <= i*8:
+		return 0xff
+	}
+	return 0xff >> uint(bits-i*8)
+}
+
+// ElementSet answers containment queries over network list elements locally.
+type ElementSet struct {
+	prefixes  []netip.Prefix
+	countries map[string]struct{}
+}
+
+// NewElementSet returns set of elements of given list type.
+func NewElementSet(listType string, elements []string) (*ElementSet, error) {
+	parsed, err := ParseElements(listType, elements)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ElementSet{countries: map[string]struct{}{}}
+
+	var prefixes []netip.Prefix
+	for _, e := range parsed {
+		if e.IsPrefix() {
+			prefixes = append(prefixes, e.Prefix)
+		} else {
+			s.countries[e.Country] = struct{}{}
+		}
+	}
+	s.prefixes = CollapsePrefixes(prefixes)
+
+	return s, nil
+}
+
+// Contains reports whether address is covered by any of the prefixes.
+func (s *ElementSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+
+	// Prefixes are sorted and disjoint, candidate is the last one starting at or before addr
+	i := sort.Search(len(s.prefixes), func(i int) bool {
+		return addr.Less(s.prefixes[i].Addr())
+	})
+
+	return i > 0 && s.prefixes[i-1].Contains(addr)
+}
+
+// ContainsIP parses address and reports whether it is covered by any of the prefixes.
+func (s *ElementSet) ContainsIP(ip string) (bool, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false, InvalidElement{Element: ip, Reason: "invalid IP address"}
+	}
+
+	return s.Contains(addr), nil
+}
+
+// ContainsCountry reports whether country code ( or subdivision ) is in the set.
+// Subdivision i.e. `US-CA` is covered also by its country.
+func (s *ElementSet) ContainsCountry(code string) bool {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := s.countries[code]; ok {
+		return true
+	}
+
+	if m := subdivisionPattern.FindStringSubmatch(code); m != nil {
+		_, ok := s.countries[m[1]]
+		return ok
+	}
+
+	return false
+}
+
+// Contains reports whether IP address is covered by elements of the list.
+func (nl *NetworkListv2) Contains(ip string) (bool, error) {
+	s, err := NewElementSet(TypeIP, nl.List)
+	if err != nil {
+		return false, err
+	}
+
+	return s.ContainsIP(ip)
+}
@@ -0,0 +1,145 @@
+package netlistv2
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseElement(t *testing.T) {
+	cases := []struct {
+		listType, element, want string
+	}{
+		{"IP", " 192.0.2.1 ", "192.0.2.1"},
+		{"IP", "192.0.2.1/32", "192.0.2.1"},
+		{"IP", "192.0.2.77/24", "192.0.2.0/24"},
+		{"IP", "::ffff:192.0.2.1", "192.0.2.1"},
+		{"IP", "2001:DB8::1/32", "2001:db8::/32"},
+		{"GEO", "us", "US"},
+		{"GEO", "us-ca", "US-CA"},
+		{"", "10.0.0.0/8", "10.0.0.0/8"},
+		{"", "de", "DE"},
+	}
+
+	for _, c := range cases {
+		e, err := ParseElement(c.listType, c.element)
+		if assert.NoError(t, err, c.element) {
+			assert.Equal(t, c.want, e.String(), c.element)
+		}
+	}
+
+	_, err := ParseElement("GEO", "192.0.2.1")
+	assert.Error(t, err)
+
+	_, err = ParseElement("IP", "US")
+	assert.Error(t, err)
+}
+
+func TestValidateElements(t *testing.T) {
+	err := ValidateElements("IP", []string{"192.0.2.1", "300.1.1.1", "10.0.0.0/33"})
+
+	var elementsErr ErrorElements
+	if assert.True(t, errors.As(err, &elementsErr)) {
+		assert.Equal(t, "ErrorElementsInvalid", elementsErr.ErrorType)
+		assert.Len(t, elementsErr.Invalid, 2)
+		assert.Equal(t, "300.1.1.1", elementsErr.Invalid[0].Element)
+	}
+
+	assert.NoError(t, ValidateElements("GEO", []string{"PL", "gb"}))
+	assert.Error(t, ValidateElements("GEO", []string{"XX"}))
+}
+
+func TestNormalizeElements(t *testing.T) {
+	got, err := NormalizeElements("IP", []string{
+		"10.0.1.0/24",
+		"10.0.0.0/24",
+		"10.0.0.128/25",
+		"192.0.2.1",
+		"192.0.2.0/32",
+		"2001:db8::/33",
+		"2001:db8:8000::/33",
+		"192.0.2.1",
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"10.0.0.0/23", "192.0.2.0/31", "2001:db8::/32"}, got)
+	}
+
+	got, err = NormalizeElements("GEO", []string{"us", "PL", "US"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"PL", "US"}, got)
+	}
+}
+
+func TestCollapsePrefixes(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/25"),
+		netip.MustParsePrefix("192.0.2.128/26"),
+		netip.MustParsePrefix("192.0.2.192/26"),
+		netip.MustParsePrefix("198.51.100.3/32"),
+		netip.MustParsePrefix("198.51.100.4/30"),
+		netip.MustParsePrefix("255.255.255.255/32"),
+		netip.MustParsePrefix("255.255.255.254/32"),
+	}
+
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.3/32"),
+		netip.MustParsePrefix("198.51.100.4/30"),
+		netip.MustParsePrefix("255.255.255.254/31"),
+	}, CollapsePrefixes(prefixes))
+}
+
+func TestElementSet(t *testing.T) {
+	set, err := NewElementSet("IP", []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for ip, want := range map[string]bool{
+		"10.1.2.3":        true,
+		"11.0.0.0":        false,
+		"192.0.2.1":       true,
+		"192.0.2.2":       false,
+		"::ffff:10.0.0.1": true,
+		"2001:db8:1::1":   true,
+		"2001:db9::1":     false,
+		"fe80::1%eth0":    false,
+		"9.255.255.255":   false,
+	} {
+		got, err := set.ContainsIP(ip)
+		assert.NoError(t, err, ip)
+		assert.Equal(t, want, got, ip)
+	}
+
+	_, err = set.ContainsIP("not-an-ip")
+	assert.Error(t, err)
+
+	geo, err := NewElementSet("GEO", []string{"US", "PL-14"})
+	if assert.NoError(t, err) {
+		assert.True(t, geo.ContainsCountry("us"))
+		assert.True(t, geo.ContainsCountry("US-CA"))
+		assert.True(t, geo.ContainsCountry("PL-14"))
+		assert.False(t, geo.ContainsCountry("PL"))
+	}
+
+	list := NetworkListv2{Type: "IP", List: []string{"203.0.113.0/24"}}
+	ok, err := list.Contains("203.0.113.9")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCreateNetworkListValidatesElements(t *testing.T) {
+	apiClient := setupEdgeClient("")
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	_, err := apiClient.CreateNetworkList(NetworkListsOptionsv2{Name: "List", Type: "IP", List: []string{"192.0.2.1", "192.0.2"}})
+
+	var elementsErr ErrorElements
+	assert.True(t, errors.As(err, &elementsErr))
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "Invalid list should not be sent")
+}
@@ -1,6 +1,9 @@
 package netlistv2
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // NetworkListErrorv2 represents the error returned from Akamai
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#errors
@@ -28,3 +31,95 @@ func (b NetworkListErrorv2) Error() string {
 
 	return msg
 }
+
+// ErrorActivation represents network list activation which failed or did
+// not finish in time, see WaitForActivation.
+type ErrorActivation struct {
+	ErrorMessage string            `json:"error_message"`
+	ErrorType    string            `json:"error_type"`
+	ListID       string            `json:"list_id"`
+	Environment  AkamaiEnvironment `json:"environment"`
+
+	// Status is the last activation status received
+	Status string `json:"status"`
+
+	// Err is the underlying error if there was one
+	Err error `json:"-"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e ErrorActivation) Error() string {
+	return fmt.Sprintf("%s: %s on %s ( status %s )", e.ErrorMessage, e.ListID, e.Environment, e.Status)
+}
+
+// Unwrap returns the underlying error so it can be inspected
+// with errors.Is and errors.As
+func (e ErrorActivation) Unwrap() error {
+	return e.Err
+}
+
+// ErrorReconcile represents desired network list which cannot be reconciled.
+type ErrorReconcile struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorType    string `json:"error_type"`
+	ListID       string `json:"list_id,omitempty"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e ErrorReconcile) Error() string {
+	return e.ErrorMessage
+}
+
+// ErrorElements represents network list elements which are not valid
+// for the list type.
+type ErrorElements struct {
+	ErrorMessage string           `json:"error_message"`
+	ErrorType    string           `json:"error_type"`
+	Invalid      []InvalidElement `json:"invalid"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e ErrorElements) Error() string {
+	reasons := make([]string, 0, len(e.Invalid))
+	for _, i := range e.Invalid {
+		reasons = append(reasons, i.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", e.ErrorMessage, strings.Join(reasons, ", "))
+}
+
+// InvalidElement describes single element which cannot be parsed.
+type InvalidElement struct {
+	Element string `json:"element"`
+	Reason  string `json:"reason"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e InvalidElement) Error() string {
+	return fmt.Sprintf("%q ( %s )", e.Element, e.Reason)
+}
+
+// errInvalidElements returns error listing all invalid elements
+func errInvalidElements(invalid []InvalidElement) ErrorElements {
+	return ErrorElements{
+		ErrorMessage: fmt.Sprintf("%d invalid network list elements", len(invalid)),
+		ErrorType:    "ErrorElementsInvalid",
+		Invalid:      invalid,
+	}
+}
+
+// ErrorImport represents network list file which cannot be imported.
+type ErrorImport struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorType    string `json:"error_type"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e ErrorImport) Error() string {
+	return e.ErrorMessage
+}
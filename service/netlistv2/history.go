@@ -0,0 +1,212 @@
+package netlistv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+)
+
+// NetworkListRevision is state of network list at given sync point.
+type NetworkListRevision struct {
+	SyncPoint  int
+	UpdatedBy  string
+	UpdateDate time.Time
+
+	// List holds elements of the list at the sync point
+	List []string
+
+	// Added and Removed are elements changed since the previous revision,
+	// both are nil when elements before the revision are not known
+	Added   []string
+	Removed []string
+}
+
+// HistoryOptions limits sync points walked by NetworkListHistory.
+type HistoryOptions struct {
+	// From is the first sync point, history starts at sync point 0 by default
+	From int
+
+	// To is the last sync point, current sync point of the list is used when 0
+	To int
+}
+
+// ElementDiff holds elements changed between two sync points of network list.
+type ElementDiff struct {
+	ListID string
+	From   *NetworkListRevision
+	To     *NetworkListRevision
+
+	Added   []string
+	Removed []string
+}
+
+// Empty reports whether elements are the same at both sync points.
+func (d *ElementDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// String returns diff in human readable form.
+func (d *ElementDiff) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "network list %s sync point %d -> %d\n", d.ListID, d.From.SyncPoint, d.To.SyncPoint)
+	fmt.Fprintf(&b, "  updated by %s at %s\n", d.To.UpdatedBy, d.To.UpdateDate.Format(time.RFC3339))
+	for _, e := range d.Added {
+		fmt.Fprintf(&b, "  + %s\n", e)
+	}
+	for _, e := range d.Removed {
+		fmt.Fprintf(&b, "  - %s\n", e)
+	}
+
+	return b.String()
+}
+
+// GetRevision returns network list elements and audit information at given sync point.
+func (nls *Netlistv2) GetRevision(ctx context.Context, listID string, syncPoint int) (*NetworkListRevision, error) {
+	snapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, syncPoint, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevision(snapshot, syncPoint), nil
+}
+
+// NetworkListHistory walks sync points of network list from the oldest one and
+// returns revisions with elements added and removed by every change. Sync points
+// which are no longer available are skipped. When history starts at sync point 0
+// the first revision reports all its elements as added. When From is set, sync point
+// From-1 is fetched as baseline of the first revision, if it is not available
+// Added and Removed of the first revision are left nil.
+//
+//	history, err := svc.NetworkListHistory(ctx, "123_BLOCKLIST", netlistv2.HistoryOptions{From: 10})
+//	for _, r := range history {
+//		fmt.Println(r.SyncPoint, r.UpdatedBy, r.UpdateDate, r.Added, r.Removed)
+//	}
+func (nls *Netlistv2) NetworkListHistory(ctx context.Context, listID string, opts HistoryOptions) ([]NetworkListRevision, error) {
+	to := opts.To
+	if to <= 0 {
+		current, err := nls.GetNetworkListWithContext(ctx, listID, ListNetworkListsOptionsv2{Extended: true})
+		if err != nil {
+			return nil, err
+		}
+		to = current.SyncPoint
+	}
+
+	var history []NetworkListRevision
+	var listType string
+
+	// baseline holds elements of previous sync point, nil when not known
+	var baseline []string
+	if opts.From > 0 {
+		snapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, opts.From-1, true)
+		switch {
+		case errors.Is(err, edgegrid.ErrNotFound):
+			nls.Client.Logger.Debugf("Sync point %d of network list %s is not available", opts.From-1, listID)
+		case err != nil:
+			return nil, err
+		default:
+			listType = snapshot.Type
+			baseline = snapshot.List
+			if baseline == nil {
+				baseline = []string{}
+			}
+		}
+	}
+
+	for syncPoint := opts.From; syncPoint <= to; syncPoint++ {
+		snapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, syncPoint, true)
+		if errors.Is(err, edgegrid.ErrNotFound) {
+			nls.Client.Logger.Debugf("Sync point %d of network list %s is not available", syncPoint, listID)
+			continue
+		}
+		if err != nil {
+			return history, err
+		}
+
+		if snapshot.Type != "" {
+			listType = snapshot.Type
+		}
+
+		revision := newRevision(snapshot, syncPoint)
+		switch {
+		case baseline != nil:
+			revision.Added, revision.Removed = diffElements(
+				normalizeElements(listType, baseline),
+				normalizeElements(listType, revision.List),
+			)
+		case opts.From <= 0 && len(history) == 0:
+			revision.Added = normalizeElements(listType, revision.List)
+		}
+
+		baseline = revision.List
+		if baseline == nil {
+			baseline = []string{}
+		}
+
+		history = append(history, *revision)
+	}
+
+	return history, nil
+}
+
+// DiffSyncPoints returns elements added and removed between two sync points of network list.
+func (nls *Netlistv2) DiffSyncPoints(ctx context.Context, listID string, from, to int) (*ElementDiff, error) {
+	fromSnapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, from, true)
+	if err != nil {
+		return nil, err
+	}
+
+	toSnapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, to, true)
+	if err != nil {
+		return nil, err
+	}
+
+	listType := toSnapshot.Type
+	if listType == "" {
+		listType = fromSnapshot.Type
+	}
+
+	diff := &ElementDiff{
+		ListID: listID,
+		From:   newRevision(fromSnapshot, from),
+		To:     newRevision(toSnapshot, to),
+	}
+	diff.Added, diff.Removed = diffElements(
+		normalizeElements(listType, fromSnapshot.List),
+		normalizeElements(listType, toSnapshot.List),
+	)
+
+	return diff, nil
+}
+
+// RollbackNetworkList brings network list back to elements, name and description
+// it had at given sync point. Changes are applied with Reconcile so options allow
+// dry run and activation of the restored list.
+func (nls *Netlistv2) RollbackNetworkList(ctx context.Context, listID string, syncPoint int, opts ReconcileOptions) (*ReconcileResult, error) {
+	snapshot, err := nls.GetActivationSnapshotWithContext(ctx, listID, syncPoint, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return nls.Reconcile(ctx, NetworkListv2{
+		UniqueID:    listID,
+		Name:        snapshot.Name,
+		Type:        snapshot.Type,
+		Description: snapshot.Description,
+		List:        snapshot.List,
+	}, opts)
+}
+
+// newRevision returns revision of network list snapshot
+func newRevision(snapshot *NetworkListv2, syncPoint int) *NetworkListRevision {
+	return &NetworkListRevision{
+		SyncPoint:  syncPoint,
+		UpdatedBy:  snapshot.UpdatedBy,
+		UpdateDate: snapshot.UpdateDate,
+		List:       snapshot.List,
+	}
+}
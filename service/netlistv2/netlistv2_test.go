@@ -1,10 +1,18 @@
 package netlistv2
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/apiheat/go-edgegrid/v6/edgegrid"
 	"github.com/jarcoal/httpmock"
@@ -37,7 +45,10 @@ func setupEdgeClient(baseURL string) *Netlistv2 {
 		WithTestingURL(targetURL)
 
 	// Create new client
-	client := New(cfg)
+	client, err := New(cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
 
 	return client
 }
@@ -276,3 +287,540 @@ func TestActivateNetworkList(t *testing.T) {
 	}
 
 }
+
+func TestGetNetworkListWithContextDeadline(t *testing.T) {
+	// Server which holds the request until the client gives up
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	//--Init API client
+	apiClient := setupEdgeClient(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	apiResp, err := apiClient.GetNetworkListWithContext(ctx, "123_TEST", ListNetworkListsOptionsv2{})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "Error should be caused by context deadline")
+	}
+	assert.Nil(t, apiResp)
+}
+
+func TestGetNetworkListNotFound(t *testing.T) {
+	//--Init API client
+	apiClient := setupEdgeClient("")
+	responseJSON := `{"detail":"Network list 123_TEST not found","instance":"/network-list/v2/network-lists/123_TEST","status":404,"title":"Not Found","type":"https://problems.luna.akamaiapis.net/network-lists/error-types/NOT-FOUND","fieldErrors":{"entry":[{"key":"uniqueId","value":["does not exist"]}]}}`
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://test.local/network-list/v2/network-lists/123_TEST",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(404, responseJSON)
+			resp.Header.Add("Content-Type", "application/problem+json")
+			resp.Header.Add("X-Request-Id", "abc-123")
+
+			return resp, nil
+		})
+
+	apiResp, err := apiClient.GetNetworkList("123_TEST", ListNetworkListsOptionsv2{})
+	assert.Nil(t, apiResp)
+
+	assert.True(t, errors.Is(err, edgegrid.ErrNotFound), "Error should match ErrNotFound")
+	assert.False(t, errors.Is(err, edgegrid.ErrConflict), "Error should not match ErrConflict")
+
+	var apiErr *edgegrid.Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 404, apiErr.Status)
+		assert.Equal(t, "Not Found", apiErr.Title)
+		assert.Equal(t, "abc-123", apiErr.RequestID)
+		assert.Equal(t, []edgegrid.FieldError{{Field: "uniqueId", Message: "does not exist"}}, apiErr.FieldErrors)
+		assert.Equal(t, responseJSON, string(apiErr.RawBody))
+	}
+
+	var listErr *NetworkListErrorv2
+	if assert.True(t, errors.As(err, &listErr), "Service error should be wrapped") {
+		assert.Equal(t, "Network list 123_TEST not found", listErr.Detail)
+	}
+}
+
+func TestNetworkListNotificationForbidden(t *testing.T) {
+	//--Init API client
+	apiClient := setupEdgeClient("")
+	responseJSON := `{"detail":"You do not have access to network list 123_TEST","status":403,"title":"Forbidden","type":"https://problems.luna.akamaiapis.net/network-lists/error-types/FORBIDDEN"}`
+
+	httpmock.ActivateNonDefault(apiClient.Client.Rclient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://test.local/network-list/v2/notifications/subscribe",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(403, responseJSON)
+			resp.Header.Add("Content-Type", "application/problem+json")
+
+			return resp, nil
+		})
+
+	err := apiClient.NetworkListNotification(Subscribe, NetworkListSubscription{
+		Recipients: []string{"noc@example.com"},
+		UniqueIds:  []string{"123_TEST"},
+	})
+
+	var apiErr *edgegrid.Error
+	if assert.True(t, errors.As(err, &apiErr), "API error should be returned") {
+		assert.Equal(t, 403, apiErr.Status)
+		assert.Equal(t, "Forbidden", apiErr.Title)
+	}
+}
+
+func TestActivationWaitOptionsDefaults(t *testing.T) {
+	w := newActivationWaitOptions(WithActivationInterval(0), WithActivationTimeout(-time.Second))
+	assert.Equal(t, defaultActivationInterval, w.Interval)
+	assert.Equal(t, defaultActivationTimeout, w.Timeout)
+
+	w = newActivationWaitOptions(WithActivationInterval(time.Second))
+	assert.Equal(t, time.Second, w.Interval)
+}
+
+func TestWaitForActivation(t *testing.T) {
+	statuses := []string{StatusPendingActivation, StatusPendingActivation, StatusActive}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network-list/v2/network-lists/123_LIST/environments/staging/status", r.URL.Path)
+
+		status := statuses[calls]
+		if calls < len(statuses)-1 {
+			calls++
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"activationId":7,"activationStatus":"%s","syncPoint":3,"uniqueId":"123_LIST"}`, status)
+	}))
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	var events []ActivationEvent
+	status, err := apiClient.WaitForActivation(context.Background(), "123_LIST", Staging,
+		WithActivationInterval(time.Millisecond),
+		WithActivationProgress(func(e ActivationEvent) { events = append(events, e) }),
+	)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusActive, status.ActivationStatus)
+	}
+
+	if assert.Len(t, events, 3) {
+		assert.True(t, events[0].Changed())
+		assert.False(t, events[1].Changed())
+		assert.Equal(t, StatusPendingActivation, events[2].PreviousStatus)
+		assert.Equal(t, 3, events[2].Attempt)
+	}
+}
+
+func TestWaitForActivationFailures(t *testing.T) {
+	status := StatusFailed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"activationId":7,"activationStatus":"%s","syncPoint":3,"uniqueId":"123_LIST"}`, status)
+	}))
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	var actErr ErrorActivation
+
+	_, err := apiClient.WaitForActivation(context.Background(), "123_LIST", Production)
+	if assert.True(t, errors.As(err, &actErr)) {
+		assert.Equal(t, "ErrorActivationFailed", actErr.ErrorType)
+		assert.Equal(t, Production, actErr.Environment)
+	}
+
+	status = StatusPendingActivation
+	_, err = apiClient.WaitForActivation(context.Background(), "123_LIST", Production,
+		WithActivationInterval(5*time.Millisecond),
+		WithActivationTimeout(20*time.Millisecond),
+	)
+	if assert.True(t, errors.As(err, &actErr)) {
+		assert.Equal(t, "ErrorActivationTimeout", actErr.ErrorType)
+		assert.Equal(t, StatusPendingActivation, actErr.Status)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = apiClient.WaitForActivation(ctx, "123_LIST", Production)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+// fakeListServer serves single network list and records mutating requests
+type fakeListServer struct {
+	list     NetworkListv2
+	requests []string
+	created  []NetworkListsOptionsv2
+	history  map[int]NetworkListv2
+}
+
+func (f *fakeListServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	path := strings.TrimPrefix(r.URL.Path, "/network-list/v2/network-lists")
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+path)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		json.NewEncoder(w).Encode(NetworkListsv2{NetworkLists: []NetworkListv2{f.list}})
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/history"):
+		var syncPoint int
+		fmt.Sscanf(path, "/123_LIST/sync-points/%d/history", &syncPoint)
+		snapshot, ok := f.history[syncPoint]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":404,"title":"Not Found"}`)
+			return
+		}
+		json.NewEncoder(w).Encode(snapshot)
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/status"):
+		fmt.Fprint(w, `{"activationStatus":"ACTIVE"}`)
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(f.list)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/activate"):
+		fmt.Fprint(w, `{"activationId":1,"activationStatus":"PENDING_ACTIVATION"}`)
+	case r.Method == http.MethodPost && (path == "" || path == "/"):
+		var opts NetworkListsOptionsv2
+		json.NewDecoder(r.Body).Decode(&opts)
+		f.created = append(f.created, opts)
+		json.NewEncoder(w).Encode(NetworkListv2{UniqueID: "456_COPY", Name: opts.Name, Type: opts.Type, Description: opts.Description, List: opts.List})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/append"):
+		var opts NetworkListsOptionsv2
+		json.NewDecoder(r.Body).Decode(&opts)
+		f.list.List = append(f.list.List, opts.List...)
+		json.NewEncoder(w).Encode(f.list)
+	case r.Method == http.MethodDelete && strings.HasSuffix(path, "/elements"):
+		element := r.URL.Query().Get("element")
+		f.requests[len(f.requests)-1] += " " + element
+		var kept []string
+		for _, e := range f.list.List {
+			if e != element {
+				kept = append(kept, e)
+			}
+		}
+		f.list.List = kept
+		json.NewEncoder(w).Encode(f.list)
+	case r.Method == http.MethodPut:
+		var mod NetworkListv2
+		json.NewDecoder(r.Body).Decode(&mod)
+		if mod.SyncPoint != f.list.SyncPoint {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.list.Name, f.list.Description, f.list.List = mod.Name, mod.Description, mod.List
+		f.list.SyncPoint++
+		json.NewEncoder(w).Encode(f.list)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	fake := &fakeListServer{list: NetworkListv2{
+		UniqueID:                   "123_LIST",
+		Name:                       "Blocklist",
+		Type:                       "IP",
+		SyncPoint:                  4,
+		List:                       []string{"1.2.3.4", "5.6.7.8", "10.0.0.0/8"},
+		StagingActivationStatus:    StatusActive,
+		ProductionActivationStatus: StatusInactive,
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	desired := NetworkListv2{UniqueID: "123_LIST", Type: "IP", List: []string{"1.2.3.4", " 5.6.7.8", "10.0.0.0/8", "192.168.0.1", "1.2.3.4"}}
+
+	// Single append is cheaper than replacing the list
+	res, err := apiClient.Reconcile(context.Background(), desired, ReconcileOptions{DryRun: true, Activate: []AkamaiEnvironment{Staging}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyIncremental, res.Plan.Strategy)
+		assert.Equal(t, []string{"192.168.0.1"}, res.Plan.Add)
+		assert.Empty(t, res.Plan.Remove)
+		assert.Equal(t, []AkamaiEnvironment{Staging}, res.Plan.Activate)
+		assert.Contains(t, res.Plan.String(), "+ 192.168.0.1")
+		assert.Empty(t, fake.requests, "Dry run should not change anything")
+	}
+
+	_, err = apiClient.Reconcile(context.Background(), desired, ReconcileOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"POST /123_LIST/append"}, fake.requests)
+	}
+
+	// Removing more elements and changing description replaces the list
+	fake.requests = nil
+	desired.Description = "Blocked addresses"
+	desired.List = []string{"1.2.3.4"}
+
+	res, err = apiClient.Reconcile(context.Background(), desired, ReconcileOptions{
+		Activate:    []AkamaiEnvironment{Production},
+		Wait:        true,
+		WaitOptions: []func(*ActivationWaitOptions){WithActivationInterval(time.Millisecond)},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyReplace, res.Plan.Strategy)
+		assert.Equal(t, []string{"5.6.7.8", "10.0.0.0/8", "192.168.0.1"}, res.Plan.Remove)
+		assert.Equal(t, []string{"PUT /123_LIST", "POST /123_LIST/environments/production/activate"}, fake.requests)
+		assert.Equal(t, []string{"1.2.3.4"}, fake.list.List)
+		assert.Equal(t, "Blocked addresses", fake.list.Description)
+		if assert.Len(t, res.Activations, 1) {
+			assert.Equal(t, StatusActive, res.Activations[0].ActivationStatus)
+		}
+	}
+
+	// Nothing to do when list is in desired state and active
+	fake.requests = nil
+	fake.list.ProductionActivationStatus = StatusActive
+	res, err = apiClient.Reconcile(context.Background(), desired, ReconcileOptions{Activate: []AkamaiEnvironment{Production}})
+	if assert.NoError(t, err) {
+		assert.False(t, res.Plan.HasChanges())
+		assert.Empty(t, fake.requests)
+	}
+
+	// Removed element is sent in the form stored by API
+	fake.requests = nil
+	fake.list.List = []string{"1.2.3.4", "192.0.2.1/32"}
+	res, err = apiClient.Reconcile(context.Background(), desired, ReconcileOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyIncremental, res.Plan.Strategy)
+		assert.Equal(t, []string{"192.0.2.1"}, res.Plan.Remove)
+		assert.Equal(t, []string{"DELETE /123_LIST/elements 192.0.2.1/32"}, fake.requests)
+		assert.Equal(t, []string{"1.2.3.4"}, fake.list.List)
+	}
+
+	_, err = apiClient.Reconcile(context.Background(), NetworkListv2{UniqueID: "123_LIST", Type: "GEO"}, ReconcileOptions{})
+	var recErr ErrorReconcile
+	if assert.True(t, errors.As(err, &recErr)) {
+		assert.Equal(t, "ErrorReconcileType", recErr.ErrorType)
+	}
+}
+
+func TestReconcileCreatesMissingList(t *testing.T) {
+	fake := &fakeListServer{list: NetworkListv2{UniqueID: "123_LIST", Name: "Blocklist", Type: "IP"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	res, err := apiClient.Reconcile(context.Background(), NetworkListv2{Name: "Countries", Type: "GEO", List: []string{"us", "CA"}}, ReconcileOptions{DryRun: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyCreate, res.Plan.Strategy)
+		assert.Equal(t, []string{"US", "CA"}, res.Plan.Add)
+	}
+}
+
+func TestExportImportNetworkList(t *testing.T) {
+	fake := &fakeListServer{list: NetworkListv2{
+		UniqueID:                   "123_LIST",
+		Name:                       "Blocklist",
+		Type:                       "IP",
+		Description:                "Blocked\nclients",
+		SyncPoint:                  7,
+		List:                       []string{"1.2.3.4", "10.0.0.0/8"},
+		ProductionActivationStatus: StatusActive,
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	for _, format := range []Format{FormatText, FormatCSV, FormatJSON} {
+		var buf bytes.Buffer
+		if !assert.NoError(t, apiClient.ExportNetworkList(context.Background(), &buf, "123_LIST", format), format) {
+			continue
+		}
+
+		s, err := ReadNetworkList(bytes.NewReader(buf.Bytes()), format)
+		if !assert.NoError(t, err, format) {
+			continue
+		}
+		assert.Equal(t, fake.list.List, s.List, format)
+
+		if format == FormatText {
+			assert.Empty(t, s.Name)
+			continue
+		}
+
+		assert.Equal(t, "Blocklist", s.Name, format)
+		assert.Equal(t, "Blocked\nclients", s.Description, format)
+		assert.Equal(t, 7, s.SyncPoint, format)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteNetworkList(&buf, &fake.list, FormatJSON))
+	assert.Contains(t, buf.String(), `"productionActivationStatus": "ACTIVE"`)
+
+	list, err := apiClient.ImportNetworkList(context.Background(), &buf, FormatJSON, ImportOptions{Name: "Blocklist copy", ContractID: "C-1", GroupID: 12})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "456_COPY", list.UniqueID)
+		assert.Equal(t, NetworkListsOptionsv2{
+			Name:        "Blocklist copy",
+			Type:        "IP",
+			Description: "Blocked\nclients",
+			GroupID:     12,
+			ContractID:  "C-1",
+			List:        []string{"1.2.3.4", "10.0.0.0/8"},
+		}, fake.created[0])
+	}
+}
+
+func TestReadNetworkList(t *testing.T) {
+	text := "# blocked addresses\n1.2.3.4\n\n  5.6.7.8  # scanner\n"
+	s, err := ReadNetworkList(strings.NewReader(text), FormatText)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, s.List)
+		assert.Equal(t, map[string]string{"5.6.7.8": "scanner"}, s.Comments)
+	}
+
+	csv := "# name: Countries\n# type: GEO\nsource,country,comment\nfeed,US,\"sanctions, 2020\"\n# disabled\nfeed,CA,\n"
+	s, err = ReadNetworkList(strings.NewReader(csv), FormatCSV)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Countries", s.Name)
+		assert.Equal(t, "GEO", s.Type)
+		assert.Equal(t, []string{"US", "CA"}, s.List)
+		assert.Equal(t, map[string]string{"US": "sanctions, 2020"}, s.Comments)
+	}
+
+	_, err = ReadNetworkList(strings.NewReader(""), Format("xml"))
+	var importErr ErrorImport
+	if assert.True(t, errors.As(err, &importErr)) {
+		assert.Equal(t, "ErrorImportFormat", importErr.ErrorType)
+	}
+
+	apiClient := setupEdgeClient("")
+	_, err = apiClient.ImportNetworkList(context.Background(), strings.NewReader("1.2.3.4"), FormatText, ImportOptions{})
+	assert.True(t, errors.As(err, &importErr))
+}
+
+func TestNetworkListHistory(t *testing.T) {
+	day := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	fake := &fakeListServer{
+		list: NetworkListv2{UniqueID: "123_LIST", Name: "Blocklist", Type: "IP", SyncPoint: 4, List: []string{"1.2.3.4", "9.9.9.9"}},
+		history: map[int]NetworkListv2{
+			1: {Name: "Blocklist", Type: "IP", SyncPoint: 1, UpdatedBy: "alice", UpdateDate: day, List: []string{"1.2.3.4"}},
+			2: {Name: "Blocklist", Type: "IP", SyncPoint: 2, UpdatedBy: "bob", UpdateDate: day.Add(time.Hour), List: []string{"1.2.3.4", "5.6.7.8"}},
+			4: {Name: "Blocklist", Type: "IP", SyncPoint: 4, UpdatedBy: "carol", UpdateDate: day.Add(2 * time.Hour), List: []string{"1.2.3.4", "9.9.9.9"}},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	history, err := apiClient.NetworkListHistory(context.Background(), "123_LIST", HistoryOptions{})
+	if assert.NoError(t, err) && assert.Len(t, history, 3) {
+		assert.Equal(t, []string{"1.2.3.4"}, history[0].Added)
+		assert.Equal(t, "bob", history[1].UpdatedBy)
+		assert.Equal(t, []string{"5.6.7.8"}, history[1].Added)
+		assert.Equal(t, 4, history[2].SyncPoint)
+		assert.Equal(t, []string{"9.9.9.9"}, history[2].Added)
+		assert.Equal(t, []string{"5.6.7.8"}, history[2].Removed)
+	}
+
+	// Sync point 2 is the baseline of history starting at 3
+	history, err = apiClient.NetworkListHistory(context.Background(), "123_LIST", HistoryOptions{From: 3})
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, []string{"9.9.9.9"}, history[0].Added)
+		assert.Equal(t, []string{"5.6.7.8"}, history[0].Removed)
+	}
+
+	// Sync point 3 is not available, first revision has no baseline
+	history, err = apiClient.NetworkListHistory(context.Background(), "123_LIST", HistoryOptions{From: 4})
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Nil(t, history[0].Added)
+		assert.Nil(t, history[0].Removed)
+	}
+
+	diff, err := apiClient.DiffSyncPoints(context.Background(), "123_LIST", 1, 4)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"9.9.9.9"}, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Equal(t, "carol", diff.To.UpdatedBy)
+		assert.Contains(t, diff.String(), "+ 9.9.9.9")
+	}
+
+	_, err = apiClient.DiffSyncPoints(context.Background(), "123_LIST", 3, 4)
+	assert.True(t, errors.Is(err, edgegrid.ErrNotFound))
+
+	res, err := apiClient.RollbackNetworkList(context.Background(), "123_LIST", 2, ReconcileOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyReplace, res.Plan.Strategy)
+		assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, fake.list.List)
+		assert.Equal(t, 5, fake.list.SyncPoint)
+	}
+}
+
+func TestListNetworkListsQuery(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"networkLists":[]}`)
+	}))
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	_, err := apiClient.ListNetworkLists(ListNetworkListsOptionsv2{TypeOflist: "GEO", Extended: true, Search: "block"})
+	assert.NoError(t, err)
+
+	pager := apiClient.ListNetworkListsPager(context.Background(), ListNetworkListsOptionsv2{TypeOflist: "IP"})
+	for pager.Next() {
+	}
+	assert.NoError(t, pager.Err())
+
+	assert.Equal(t, []string{
+		"extended=true&includeElements=false&listType=GEO&search=block",
+		"extended=false&includeElements=false&listType=IP",
+	}, queries)
+}
+
+func TestGetNetworkListElementsPager(t *testing.T) {
+	elements := []string{"1.1.1.1", "2.2.2.2", "3.3.3.3", "4.4.4.4", "5.5.5.5"}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(elements) {
+			end = len(elements)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(NetworkListv2{UniqueID: "123_LIST", ElementCount: len(elements), List: elements[offset:end]})
+	}))
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	var got []string
+	pager := apiClient.GetNetworkListElementsPager(context.Background(), "123_LIST", 2)
+	for pager.Next() {
+		got = append(got, pager.Value())
+	}
+
+	assert.NoError(t, pager.Err())
+	assert.Equal(t, elements, got)
+	assert.Equal(t, []string{
+		"extended=false&includeElements=true&limit=2",
+		"extended=false&includeElements=true&limit=2&offset=2",
+		"extended=false&includeElements=true&limit=2&offset=4",
+	}, queries)
+}
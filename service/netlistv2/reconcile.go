@@ -0,0 +1,412 @@
+package netlistv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ReconcileStrategy describes how network list is brought to desired state.
+type ReconcileStrategy string
+
+const (
+	// StrategyNone means that list is already in desired state
+	StrategyNone ReconcileStrategy = "none"
+
+	// StrategyIncremental appends missing elements and removes extra ones one by one
+	StrategyIncremental ReconcileStrategy = "incremental"
+
+	// StrategyReplace replaces whole list with ModifyNetworkList
+	StrategyReplace ReconcileStrategy = "replace"
+
+	// StrategyCreate creates list which does not exist yet
+	StrategyCreate ReconcileStrategy = "create"
+)
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	// DryRun only computes the plan, nothing is changed
+	DryRun bool
+
+	// Activate lists environments where list is activated after it was changed
+	// or when it is not active there yet
+	Activate []AkamaiEnvironment
+
+	// ActivationOptions are used for activation requests
+	ActivationOptions NetworkListActivationOptsv2
+
+	// Wait blocks until activations finish, see WaitForActivation
+	Wait        bool
+	WaitOptions []func(*ActivationWaitOptions)
+}
+
+// ReconcilePlan describes changes needed to bring network list to desired state.
+type ReconcilePlan struct {
+	ListID   string
+	Name     string
+	Type     string
+	Strategy ReconcileStrategy
+
+	// Name and description changes, empty when unchanged
+	NameChange        *FieldChange
+	DescriptionChange *FieldChange
+
+	// Elements to be added and removed
+	Add    []string
+	Remove []string
+
+	// Activate lists environments where list will be activated
+	Activate []AkamaiEnvironment
+
+	// elements is the desired list, syncPoint the version it was compared with
+	elements  []string
+	syncPoint int
+
+	// stored maps canonical elements of current list to the form stored by API
+	stored map[string]string
+}
+
+// FieldChange describes change of a single network list field.
+type FieldChange struct {
+	From string
+	To   string
+}
+
+// ReconcileResult is the outcome of Reconcile.
+type ReconcileResult struct {
+	Plan *ReconcilePlan
+
+	// List is the network list after changes, nil for dry run or when nothing changed
+	List *NetworkListv2
+
+	// Activations holds status of every activation which was requested
+	Activations []*NetworkListActivationStatusv2
+}
+
+// HasChanges reports whether list has to be created or modified.
+func (p *ReconcilePlan) HasChanges() bool {
+	return p.Strategy != StrategyNone
+}
+
+// Requests returns number of API calls needed to apply the changes, activations excluded.
+func (p *ReconcilePlan) Requests() int {
+	switch p.Strategy {
+	case StrategyCreate, StrategyReplace:
+		return 1
+	case StrategyIncremental:
+		return incrementalRequests(len(p.Add), len(p.Remove))
+	}
+	return 0
+}
+
+// String returns plan in human readable form.
+func (p *ReconcilePlan) String() string {
+	var b strings.Builder
+
+	id := p.ListID
+	if id == "" {
+		id = "(new)"
+	}
+	fmt.Fprintf(&b, "network list %s %q ( %s )\n", id, p.Name, p.Type)
+	fmt.Fprintf(&b, "  strategy: %s, requests: %d\n", p.Strategy, p.Requests())
+
+	if p.NameChange != nil {
+		fmt.Fprintf(&b, "  ~ name: %q -> %q\n", p.NameChange.From, p.NameChange.To)
+	}
+	if p.DescriptionChange != nil {
+		fmt.Fprintf(&b, "  ~ description: %q -> %q\n", p.DescriptionChange.From, p.DescriptionChange.To)
+	}
+	for _, e := range p.Add {
+		fmt.Fprintf(&b, "  + %s\n", e)
+	}
+	for _, e := range p.Remove {
+		fmt.Fprintf(&b, "  - %s\n", e)
+	}
+	for _, env := range p.Activate {
+		fmt.Fprintf(&b, "  activate: %s\n", env)
+	}
+
+	return b.String()
+}
+
+// PlanReconcile compares desired network list with the current one and returns
+// changes needed to bring it to desired state. List is looked up by UniqueID or,
+// when it is empty, by name and type.
+func (nls *Netlistv2) PlanReconcile(ctx context.Context, desired NetworkListv2, opts ReconcileOptions) (*ReconcilePlan, error) {
+	current, err := nls.findNetworkList(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	want := normalizeElements(desired.Type, desired.List)
+
+	plan := &ReconcilePlan{
+		Name:     desired.Name,
+		Type:     desired.Type,
+		elements: want,
+	}
+
+	if current == nil {
+		plan.Strategy = StrategyCreate
+		plan.Add = want
+		plan.Activate = opts.Activate
+		return plan, nil
+	}
+
+	if desired.Type != "" && !strings.EqualFold(desired.Type, current.Type) {
+		return nil, ErrorReconcile{
+			ErrorMessage: fmt.Sprintf("Type of network list cannot be changed from %s to %s", current.Type, desired.Type),
+			ErrorType:    "ErrorReconcileType",
+			ListID:       current.UniqueID,
+		}
+	}
+
+	plan.ListID = current.UniqueID
+	plan.Type = current.Type
+	plan.syncPoint = current.SyncPoint
+	if plan.Name == "" {
+		plan.Name = current.Name
+	}
+
+	if desired.Name != "" && desired.Name != current.Name {
+		plan.NameChange = &FieldChange{From: current.Name, To: desired.Name}
+	}
+	if desired.Description != current.Description {
+		plan.DescriptionChange = &FieldChange{From: current.Description, To: desired.Description}
+	}
+
+	plan.stored = make(map[string]string, len(current.List))
+	for _, e := range current.List {
+		canonical := canonicalElement(current.Type, strings.TrimSpace(e))
+		if _, ok := plan.stored[canonical]; !ok {
+			plan.stored[canonical] = e
+		}
+	}
+
+	plan.Add, plan.Remove = diffElements(normalizeElements(current.Type, current.List), want)
+
+	switch {
+	case plan.NameChange != nil || plan.DescriptionChange != nil:
+		// Metadata can be changed only by replacing the list
+		plan.Strategy = StrategyReplace
+	case len(plan.Add) == 0 && len(plan.Remove) == 0:
+		plan.Strategy = StrategyNone
+	case incrementalRequests(len(plan.Add), len(plan.Remove)) > 1:
+		plan.Strategy = StrategyReplace
+	default:
+		plan.Strategy = StrategyIncremental
+	}
+
+	for _, env := range opts.Activate {
+		if plan.HasChanges() || activationStatus(current, env) != StatusActive {
+			plan.Activate = append(plan.Activate, env)
+		}
+	}
+
+	return plan, nil
+}
+
+// Reconcile brings network list to desired state with as few API calls as possible
+// and optionally activates it. With DryRun only the plan is returned.
+//
+//	res, err := svc.Reconcile(ctx, desired, netlistv2.ReconcileOptions{DryRun: true})
+//	if err != nil {
+//		// handle error
+//	}
+//	fmt.Print(res.Plan)
+func (nls *Netlistv2) Reconcile(ctx context.Context, desired NetworkListv2, opts ReconcileOptions) (*ReconcileResult, error) {
+	plan, err := nls.PlanReconcile(ctx, desired, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{Plan: plan}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if result.List, err = nls.applyPlan(ctx, plan, desired); err != nil {
+		return result, err
+	}
+
+	listID := plan.ListID
+	if result.List != nil {
+		listID = result.List.UniqueID
+	}
+
+	for _, env := range plan.Activate {
+		status, err := nls.ActivateNetworkListWithContext(ctx, listID, env, opts.ActivationOptions)
+		if err != nil {
+			return result, err
+		}
+
+		if opts.Wait {
+			if status, err = nls.WaitForActivation(ctx, listID, env, opts.WaitOptions...); err != nil {
+				return result, err
+			}
+		}
+
+		result.Activations = append(result.Activations, status)
+	}
+
+	return result, nil
+}
+
+// applyPlan executes changes described by plan
+func (nls *Netlistv2) applyPlan(ctx context.Context, plan *ReconcilePlan, desired NetworkListv2) (*NetworkListv2, error) {
+	switch plan.Strategy {
+	case StrategyCreate:
+		return nls.CreateNetworkListWithContext(ctx, NetworkListsOptionsv2{
+			Name:        desired.Name,
+			Type:        desired.Type,
+			Description: desired.Description,
+			List:        plan.Add,
+		})
+
+	case StrategyReplace:
+		// Sync point of the compared list makes API reject the change
+		// when the list was modified in the meantime
+		return nls.ModifyNetworkListWithContext(ctx, NetworkListv2{
+			UniqueID:    plan.ListID,
+			Name:        plan.Name,
+			Type:        plan.Type,
+			Description: desired.Description,
+			SyncPoint:   plan.syncPoint,
+			List:        plan.elements,
+		})
+
+	case StrategyIncremental:
+		var list *NetworkListv2
+		var err error
+
+		if len(plan.Add) > 0 {
+			list, err = nls.AddNetworkListElementWithContext(ctx, plan.ListID, NetworkListsOptionsv2{List: plan.Add})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Elements are removed in the form API stores them
+		for _, e := range plan.Remove {
+			if stored, ok := plan.stored[e]; ok {
+				e = stored
+			}
+
+			if list, err = nls.RemoveNetworkListElementWithContext(ctx, plan.ListID, e); err != nil {
+				return nil, err
+			}
+		}
+
+		return list, nil
+	}
+
+	return nil, nil
+}
+
+// findNetworkList returns current state of the list, nil when it does not exist
+func (nls *Netlistv2) findNetworkList(ctx context.Context, desired NetworkListv2) (*NetworkListv2, error) {
+	if desired.UniqueID != "" {
+		return nls.GetNetworkListWithContext(ctx, desired.UniqueID, ListNetworkListsOptionsv2{
+			Extended:        true,
+			IncludeElements: true,
+		})
+	}
+
+	if desired.Name == "" {
+		return nil, ErrorReconcile{
+			ErrorMessage: "Desired network list needs unique ID or name",
+			ErrorType:    "ErrorReconcileIdentity",
+		}
+	}
+
+	lists, err := nls.ListNetworkListsWithContext(ctx, ListNetworkListsOptionsv2{
+		Extended:        true,
+		IncludeElements: true,
+		Search:          desired.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *NetworkListv2
+	for i, l := range lists.NetworkLists {
+		if l.Name != desired.Name || (desired.Type != "" && !strings.EqualFold(l.Type, desired.Type)) {
+			continue
+		}
+
+		if found != nil {
+			return nil, ErrorReconcile{
+				ErrorMessage: fmt.Sprintf("More network lists are named %q, set unique ID", desired.Name),
+				ErrorType:    "ErrorReconcileIdentity",
+			}
+		}
+		found = &lists.NetworkLists[i]
+	}
+
+	return found, nil
+}
+
+// activationStatus returns status of the list on given environment
+func activationStatus(list *NetworkListv2, env AkamaiEnvironment) string {
+	if env == Production {
+		return list.ProductionActivationStatus
+	}
+	return list.StagingActivationStatus
+}
+
+// incrementalRequests returns number of calls needed to append and remove elements one by one
+func incrementalRequests(add, remove int) int {
+	requests := remove
+	if add > 0 {
+		requests++
+	}
+	return requests
+}
+
+// normalizeElements trims elements, drops empty and duplicate ones. Elements are
+// written in canonical form ( see ParseElement ), invalid ones are kept as they are.
+func normalizeElements(listType string, elements []string) []string {
+	seen := map[string]struct{}{}
+
+	var normalized []string
+	for _, e := range elements {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+
+		e = canonicalElement(listType, e)
+
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		normalized = append(normalized, e)
+	}
+
+	return normalized
+}
+
+// diffElements returns elements of want missing in have and elements of have missing in want
+func diffElements(have, want []string) (add, remove []string) {
+	haveSet := make(map[string]struct{}, len(have))
+	for _, e := range have {
+		haveSet[e] = struct{}{}
+	}
+
+	wantSet := make(map[string]struct{}, len(want))
+	for _, e := range want {
+		wantSet[e] = struct{}{}
+		if _, ok := haveSet[e]; !ok {
+			add = append(add, e)
+		}
+	}
+
+	for _, e := range have {
+		if _, ok := wantSet[e]; !ok {
+			remove = append(remove, e)
+		}
+	}
+
+	return add, remove
+}
@@ -8,6 +8,13 @@ import (
 const (
 	// Represents base path used for Akamai calls towards APIs.
 	basePath = "/network-list/v2/network-lists"
+
+	// Represents service name used to look up service specific configuration.
+	serviceName = "netlistv2"
+
+	// Represents default rate limit ( requests per second and burst ) used to stay within API quotas.
+	defaultRateLimit = 10
+	defaultRateBurst = 10
 )
 
 // Netlistv2 provides the API operation methods for making requests to
@@ -19,20 +26,25 @@ type Netlistv2 struct {
 
 // New creates a new instance of the Netlistv2 client with a config.
 // If additional configuration is needed for the client instance use the optional
-// edgegrid.Config parameter to add your extra config.
+// edgegrid.Config parameter to add your extra config. Error is returned
+// when the client cannot be created i.e. credentials are missing.
 //
 // Example:
 //     // Create a netlistv2 client from just a config.
-//     svc := netlistv2.New(myConfig))
-func New(cfgs *edgegrid.Config) *Netlistv2 {
+//     svc, err := netlistv2.New(myConfig)
+func New(cfgs *edgegrid.Config) (*Netlistv2, error) {
 	return newClient(cfgs)
 }
 
 // newClient creates, initializes and returns a new service client instance.
-func newClient(cfg *edgegrid.Config) *Netlistv2 {
-	svc := &Netlistv2{
-		Client: client.New(cfg),
+func newClient(cfg *edgegrid.Config) (*Netlistv2, error) {
+	c, err := client.New(cfg,
+		client.WithServiceName(serviceName),
+		client.WithRateLimiter(client.NewTokenBucket(defaultRateLimit, defaultRateBurst)),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return svc
+	return &Netlistv2{Client: c}, nil
 }
@@ -0,0 +1,372 @@
+package netlistv2
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is file format of exported network list.
+type Format string
+
+const (
+	// FormatText is plain list with one element per line. Lines starting
+	// with `#` and text after `#` are comments.
+	FormatText Format = "text"
+
+	// FormatCSV is CSV with `element` and `comment` columns, list metadata
+	// is written in `# key: value` comment lines before the header.
+	FormatCSV Format = "csv"
+
+	// FormatJSON is full snapshot of the list, see NetworkListSnapshot
+	FormatJSON Format = "json"
+)
+
+// snapshotVersion is version of JSON snapshot format
+const snapshotVersion = 1
+
+// NetworkListSnapshot is exported network list. Field names follow the API
+// so network list returned by API can be imported as well.
+type NetworkListSnapshot struct {
+	Version    int       `json:"version,omitempty"`
+	ExportedAt time.Time `json:"exportedAt,omitempty"`
+
+	UniqueID    string `json:"uniqueId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	SyncPoint   int    `json:"syncPoint"`
+
+	ProductionActivationStatus string `json:"productionActivationStatus,omitempty"`
+	StagingActivationStatus    string `json:"stagingActivationStatus,omitempty"`
+
+	CreateDate time.Time `json:"createDate,omitempty"`
+	CreatedBy  string    `json:"createdBy,omitempty"`
+	UpdateDate time.Time `json:"updateDate,omitempty"`
+	UpdatedBy  string    `json:"updatedBy,omitempty"`
+
+	List []string `json:"list"`
+
+	// Comments holds comments of elements, keyed by element
+	Comments map[string]string `json:"comments,omitempty"`
+}
+
+// ImportOptions overrides metadata of imported list and sets where it is created.
+type ImportOptions struct {
+	Name        string
+	Type        string
+	Description string
+	GroupID     int
+	ContractID  string
+}
+
+// NewNetworkListSnapshot returns snapshot of the network list.
+func NewNetworkListSnapshot(list *NetworkListv2) *NetworkListSnapshot {
+	return &NetworkListSnapshot{
+		Version:                    snapshotVersion,
+		ExportedAt:                 time.Now().UTC(),
+		UniqueID:                   list.UniqueID,
+		Name:                       list.Name,
+		Type:                       list.Type,
+		Description:                list.Description,
+		SyncPoint:                  list.SyncPoint,
+		ProductionActivationStatus: list.ProductionActivationStatus,
+		StagingActivationStatus:    list.StagingActivationStatus,
+		CreateDate:                 list.CreateDate,
+		CreatedBy:                  list.CreatedBy,
+		UpdateDate:                 list.UpdateDate,
+		UpdatedBy:                  list.UpdatedBy,
+		List:                       list.List,
+	}
+}
+
+// CreateOptions returns options creating copy of the list, non empty
+// import options take precedence over snapshot metadata.
+func (s *NetworkListSnapshot) CreateOptions(opts ImportOptions) NetworkListsOptionsv2 {
+	create := NetworkListsOptionsv2{
+		Name:        s.Name,
+		Type:        s.Type,
+		Description: s.Description,
+		GroupID:     opts.GroupID,
+		ContractID:  opts.ContractID,
+		List:        s.List,
+	}
+
+	if opts.Name != "" {
+		create.Name = opts.Name
+	}
+	if opts.Type != "" {
+		create.Type = opts.Type
+	}
+	if opts.Description != "" {
+		create.Description = opts.Description
+	}
+
+	return create
+}
+
+// WriteNetworkList writes network list in given format.
+func WriteNetworkList(w io.Writer, list *NetworkListv2, format Format) error {
+	return writeSnapshot(w, NewNetworkListSnapshot(list), format)
+}
+
+// writeSnapshot writes snapshot in given format
+func writeSnapshot(w io.Writer, s *NetworkListSnapshot, format Format) error {
+	switch format {
+	case FormatText:
+		bw := bufio.NewWriter(w)
+		for _, e := range s.List {
+			if c := s.Comments[e]; c != "" {
+				fmt.Fprintf(bw, "%s # %s\n", e, c)
+				continue
+			}
+			fmt.Fprintln(bw, e)
+		}
+		return bw.Flush()
+
+	case FormatCSV:
+		bw := bufio.NewWriter(w)
+		for _, m := range [][2]string{
+			{"uniqueId", s.UniqueID},
+			{"name", s.Name},
+			{"type", s.Type},
+			{"description", s.Description},
+			{"syncPoint", strconv.Itoa(s.SyncPoint)},
+		} {
+			if m[1] != "" {
+				fmt.Fprintf(bw, "# %s: %s\n", m[0], strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(m[1]))
+			}
+		}
+
+		cw := csv.NewWriter(bw)
+		cw.Write([]string{"element", "comment"})
+		for _, e := range s.List {
+			cw.Write([]string{e, s.Comments[e]})
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		return bw.Flush()
+
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+
+	return errUnknownFormat(format)
+}
+
+// ReadNetworkList reads network list written in given format. Empty
+// lines are skipped, metadata is set only when format holds it.
+func ReadNetworkList(r io.Reader, format Format) (*NetworkListSnapshot, error) {
+	switch format {
+	case FormatText:
+		return readText(r)
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		s := &NetworkListSnapshot{}
+		if err := json.NewDecoder(r).Decode(s); err != nil {
+			return nil, ErrorImport{
+				ErrorMessage: fmt.Sprintf("Invalid JSON snapshot: %s", err),
+				ErrorType:    "ErrorImportSyntax",
+			}
+		}
+		return s, nil
+	}
+
+	return nil, errUnknownFormat(format)
+}
+
+// readText reads plain list of elements
+func readText(r io.Reader) (*NetworkListSnapshot, error) {
+	s := &NetworkListSnapshot{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		element, comment := scanner.Text(), ""
+		if i := strings.Index(element, "#"); i >= 0 {
+			element, comment = element[:i], strings.TrimSpace(element[i+1:])
+		}
+
+		s.add(strings.TrimSpace(element), comment)
+	}
+
+	return s, scanner.Err()
+}
+
+// readCSV reads metadata comments and CSV records of elements
+func readCSV(r io.Reader) (*NetworkListSnapshot, error) {
+	s := &NetworkListSnapshot{}
+
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil || b[0] != '#' {
+			break
+		}
+
+		line, err := br.ReadString('\n')
+		s.setMetadata(strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		if err != nil {
+			break
+		}
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	elementCol, commentCol := 0, 1
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrorImport{
+				ErrorMessage: fmt.Sprintf("Invalid CSV: %s", err),
+				ErrorType:    "ErrorImportSyntax",
+			}
+		}
+
+		if first && isHeader(record) {
+			commentCol = -1
+			for i, col := range record {
+				switch name := strings.ToLower(strings.TrimSpace(col)); {
+				case isElementColumn(name):
+					elementCol = i
+				case name == "comment" || name == "comments":
+					commentCol = i
+				}
+			}
+			continue
+		}
+
+		var element, comment string
+		if elementCol < len(record) {
+			element = strings.TrimSpace(record[elementCol])
+		}
+		if commentCol >= 0 && commentCol < len(record) {
+			comment = strings.TrimSpace(record[commentCol])
+		}
+		s.add(element, comment)
+	}
+
+	return s, nil
+}
+
+// isHeader reports whether CSV record is header with column names
+func isHeader(record []string) bool {
+	for _, col := range record {
+		if isElementColumn(strings.ToLower(strings.TrimSpace(col))) {
+			return true
+		}
+	}
+	return false
+}
+
+// isElementColumn reports whether column of given name holds elements
+func isElementColumn(name string) bool {
+	switch name {
+	case "element", "elements", "ip", "cidr", "country":
+		return true
+	}
+	return false
+}
+
+// setMetadata sets snapshot field from `key: value` comment
+func (s *NetworkListSnapshot) setMetadata(comment string) {
+	i := strings.Index(comment, ":")
+	if i < 0 {
+		return
+	}
+
+	value := strings.TrimSpace(comment[i+1:])
+	value = strings.NewReplacer("\\\\", "\\", "\\n", "\n").Replace(value)
+
+	switch strings.ToLower(strings.TrimSpace(comment[:i])) {
+	case "uniqueid":
+		s.UniqueID = value
+	case "name":
+		s.Name = value
+	case "type":
+		s.Type = value
+	case "description":
+		s.Description = value
+	case "syncpoint":
+		s.SyncPoint, _ = strconv.Atoi(value)
+	}
+}
+
+// add appends element and its comment
+func (s *NetworkListSnapshot) add(element, comment string) {
+	if element == "" {
+		return
+	}
+
+	s.List = append(s.List, element)
+	if comment != "" {
+		if s.Comments == nil {
+			s.Comments = map[string]string{}
+		}
+		s.Comments[element] = comment
+	}
+}
+
+// ExportNetworkList fetches network list with its elements and writes it in given format.
+func (nls *Netlistv2) ExportNetworkList(ctx context.Context, w io.Writer, listID string, format Format) error {
+	list, err := nls.GetNetworkListWithContext(ctx, listID, ListNetworkListsOptionsv2{
+		Extended:        true,
+		IncludeElements: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return WriteNetworkList(w, list, format)
+}
+
+// ImportNetworkList reads network list in given format and creates it. Lists
+// exported from other account keep name, type and description unless they
+// are overridden by options.
+//
+//	list, err := svc.ImportNetworkList(ctx, f, netlistv2.FormatJSON, netlistv2.ImportOptions{ContractID: "C-1", GroupID: 123})
+func (nls *Netlistv2) ImportNetworkList(ctx context.Context, r io.Reader, format Format, opts ImportOptions) (*NetworkListv2, error) {
+	s, err := ReadNetworkList(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return nls.RestoreNetworkList(ctx, s, opts)
+}
+
+// RestoreNetworkList creates network list from snapshot.
+func (nls *Netlistv2) RestoreNetworkList(ctx context.Context, s *NetworkListSnapshot, opts ImportOptions) (*NetworkListv2, error) {
+	create := s.CreateOptions(opts)
+	if create.Name == "" || create.Type == "" {
+		return nil, ErrorImport{
+			ErrorMessage: "Name and type of imported network list are required",
+			ErrorType:    "ErrorImportMetadata",
+		}
+	}
+
+	return nls.CreateNetworkListWithContext(ctx, create)
+}
+
+// errUnknownFormat returns error for unsupported format
+func errUnknownFormat(format Format) ErrorImport {
+	return ErrorImport{
+		ErrorMessage: fmt.Sprintf("Unknown format %q", format),
+		ErrorType:    "ErrorImportFormat",
+	}
+}
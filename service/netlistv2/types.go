@@ -28,7 +28,7 @@ type NetworkListsv2 struct {
 // NetworkListv2 represents the network list structure
 // Akamai API docs: https://developer.akamai.com/api/luna/network-list
 type NetworkListv2 struct {
-	NetworkListType    string `json:"networkListType,omitempty`
+	NetworkListType    string `json:"networkListType,omitempty"`
 	AccessControlGroup string `json:"accessControlGroup,omitempty"`
 	Name               string `json:"name,omitempty"`
 	Description        string `json:"description,omitempty"`
@@ -79,10 +79,24 @@ type NetworkListsOptionsv2 struct {
 // ListNetworkListsOptionsv2 represents the available options for listing network lists
 // Akamai API docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html
 type ListNetworkListsOptionsv2 struct {
-	TypeOflist      string
-	Extended        bool
-	IncludeElements bool
-	Search          string
+	TypeOflist      string `url:"listType,omitempty"`
+	Extended        bool   `url:"extended"`
+	IncludeElements bool   `url:"includeElements"`
+	Search          string `url:"search,omitempty"`
+
+	// ElementsOffset and ElementsLimit page elements of large list returned by GetNetworkList
+	ElementsOffset int `url:"offset,omitempty"`
+	ElementsLimit  int `url:"limit,omitempty"`
+}
+
+// removeElementOptions is query of RemoveNetworkListElement
+type removeElementOptions struct {
+	Element string `url:"element"`
+}
+
+// activationSnapshotOptions is query of GetActivationSnapshot
+type activationSnapshotOptions struct {
+	Extended bool `url:"extended"`
 }
 
 // NetworkListActivationOptsv2 represents object used for activating network list in Akamai
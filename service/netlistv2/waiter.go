@@ -0,0 +1,172 @@
+package netlistv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
+
+// Activation statuses reported by GetActivationStatus
+const (
+	StatusInactive            = "INACTIVE"
+	StatusPendingActivation   = "PENDING_ACTIVATION"
+	StatusActive              = "ACTIVE"
+	StatusModified            = "MODIFIED"
+	StatusPendingDeactivation = "PENDING_DEACTIVATION"
+	StatusFailed              = "FAILED"
+)
+
+const (
+	// defaultActivationInterval is time between activation status checks
+	defaultActivationInterval = 10 * time.Second
+
+	// defaultActivationTimeout is time after which waiting for activation is abandoned
+	defaultActivationTimeout = 30 * time.Minute
+)
+
+// ActivationEvent describes activation status received while waiting for activation.
+type ActivationEvent struct {
+	ListID      string
+	Environment AkamaiEnvironment
+
+	// Status is the current activation status, PreviousStatus is the one
+	// received by previous check and is empty for the first check
+	Status         string
+	PreviousStatus string
+
+	ActivationID int
+	SyncPoint    int
+
+	// Attempt is number of the status check, Elapsed time since waiting started
+	Attempt int
+	Elapsed time.Duration
+}
+
+// Changed reports whether activation status changed since previous check.
+func (e ActivationEvent) Changed() bool {
+	return e.Status != e.PreviousStatus
+}
+
+// ActivationWaitOptions configures WaitForActivation, see WithActivation* functions.
+type ActivationWaitOptions struct {
+	Interval   time.Duration
+	Timeout    time.Duration
+	OnProgress func(ActivationEvent)
+}
+
+// WithActivationInterval sets time between activation status checks, 10 seconds by default.
+func WithActivationInterval(interval time.Duration) func(*ActivationWaitOptions) {
+	return func(w *ActivationWaitOptions) {
+		w.Interval = interval
+	}
+}
+
+// WithActivationTimeout sets time after which waiting is abandoned, 30 minutes by default.
+func WithActivationTimeout(timeout time.Duration) func(*ActivationWaitOptions) {
+	return func(w *ActivationWaitOptions) {
+		w.Timeout = timeout
+	}
+}
+
+// WithActivationProgress sets function called with every received activation status.
+func WithActivationProgress(onProgress func(ActivationEvent)) func(*ActivationWaitOptions) {
+	return func(w *ActivationWaitOptions) {
+		w.OnProgress = onProgress
+	}
+}
+
+// newActivationWaitOptions applies options, non-positive interval and timeout are defaulted
+func newActivationWaitOptions(options ...func(*ActivationWaitOptions)) *ActivationWaitOptions {
+	w := &ActivationWaitOptions{}
+	for _, opt := range options {
+		opt(w)
+	}
+
+	if w.Interval <= 0 {
+		w.Interval = defaultActivationInterval
+	}
+	if w.Timeout <= 0 {
+		w.Timeout = defaultActivationTimeout
+	}
+
+	return w
+}
+
+// WaitForActivation polls activation status of the network list until it becomes
+// ACTIVE. ErrorActivation is returned when activation fails or does not finish
+// before the timeout, cancelling ctx stops waiting with the context's error.
+//
+//	_, err := svc.ActivateNetworkList(listID, netlistv2.Production, opts)
+//	...
+//	status, err := svc.WaitForActivation(ctx, listID, netlistv2.Production,
+//		netlistv2.WithActivationTimeout(15*time.Minute),
+//		netlistv2.WithActivationProgress(func(e netlistv2.ActivationEvent) {
+//			log.Printf("%s: %s", e.ListID, e.Status)
+//		}),
+//	)
+func (nls *Netlistv2) WaitForActivation(ctx context.Context, listID string, env AkamaiEnvironment, options ...func(*ActivationWaitOptions)) (*NetworkListActivationStatusv2, error) {
+	w := newActivationWaitOptions(options...)
+
+	start := time.Now()
+	deadline := start.Add(w.Timeout)
+
+	var previous string
+	for attempt := 1; ; attempt++ {
+		status, err := nls.GetActivationStatusWithContext(ctx, listID, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.OnProgress != nil {
+			w.OnProgress(ActivationEvent{
+				ListID:         listID,
+				Environment:    env,
+				Status:         status.ActivationStatus,
+				PreviousStatus: previous,
+				ActivationID:   status.ActivationID,
+				SyncPoint:      status.SyncPoint,
+				Attempt:        attempt,
+				Elapsed:        time.Since(start),
+			})
+		}
+
+		if status.ActivationStatus != previous {
+			nls.Client.Logger.Debugf("Network list %s activation status on %s is %s", listID, env, status.ActivationStatus)
+		}
+		previous = status.ActivationStatus
+
+		switch status.ActivationStatus {
+		case StatusActive:
+			return status, nil
+		case StatusFailed:
+			return status, ErrorActivation{
+				ErrorMessage: "Network list activation failed",
+				ErrorType:    "ErrorActivationFailed",
+				ListID:       listID,
+				Environment:  env,
+				Status:       status.ActivationStatus,
+			}
+		}
+
+		wait := w.Interval
+		if remaining := time.Until(deadline); remaining < wait {
+			wait = remaining
+		}
+
+		if wait <= 0 {
+			return status, ErrorActivation{
+				ErrorMessage: "Network list activation timed out",
+				ErrorType:    "ErrorActivationTimeout",
+				ListID:       listID,
+				Environment:  env,
+				Status:       status.ActivationStatus,
+				Err:          context.DeadlineExceeded,
+			}
+		}
+
+		if err := client.SleepWithContext(ctx, wait); err != nil {
+			return status, err
+		}
+	}
+}
@@ -1,13 +1,24 @@
 package siteshieldv1
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
 )
 
 //ListMaps Lists siteshield maps available in the account
 func (sss *Siteshieldv1) ListMaps() (*SiteShieldMaps, error) {
+	return sss.ListMapsWithContext(context.Background())
+}
+
+// ListMapsWithContext is the same as ListMaps with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (sss *Siteshieldv1) ListMapsWithContext(ctx context.Context) (*SiteShieldMaps, error) {
+	ctx = client.WithOperation(ctx, "ListMaps")
 	// Create and execute request
 	resp, err := sss.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(SiteShieldMaps{}).
 		SetError(SiteshieldErrorv1{}).
 		Get(basePath)
@@ -17,10 +28,7 @@ func (sss *Siteshieldv1) ListMaps() (*SiteShieldMaps, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*SiteshieldErrorv1)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*SiteShieldMaps), nil
@@ -28,8 +36,16 @@ func (sss *Siteshieldv1) ListMaps() (*SiteShieldMaps, error) {
 
 //GetMap Retrieves specific map based on ID
 func (sss *Siteshieldv1) GetMap(id string) (*SiteShieldMap, error) {
+	return sss.GetMapWithContext(context.Background(), id)
+}
+
+// GetMapWithContext is the same as GetMap with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (sss *Siteshieldv1) GetMapWithContext(ctx context.Context, id string) (*SiteShieldMap, error) {
+	ctx = client.WithOperation(ctx, "GetMap")
 	// Create and execute request
 	resp, err := sss.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(SiteShieldMap{}).
 		SetError(SiteshieldErrorv1{}).
 		Get(fmt.Sprintf("%s/%s", basePath, id))
@@ -39,10 +55,7 @@ func (sss *Siteshieldv1) GetMap(id string) (*SiteShieldMap, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*SiteshieldErrorv1)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*SiteShieldMap), nil
@@ -50,8 +63,16 @@ func (sss *Siteshieldv1) GetMap(id string) (*SiteShieldMap, error) {
 
 //AcknowledgeMap Acknowledges specific map based on ID
 func (sss *Siteshieldv1) AcknowledgeMap(id string) (*SiteShieldMap, error) {
+	return sss.AcknowledgeMapWithContext(context.Background(), id)
+}
+
+// AcknowledgeMapWithContext is the same as AcknowledgeMap with the addition of
+// the ability to pass a context for cancellation and deadlines.
+func (sss *Siteshieldv1) AcknowledgeMapWithContext(ctx context.Context, id string) (*SiteShieldMap, error) {
+	ctx = client.WithOperation(ctx, "AcknowledgeMap")
 	// Create and execute request
 	resp, err := sss.Client.Rclient.R().
+		SetContext(ctx).
 		SetResult(SiteShieldMap{}).
 		SetError(SiteshieldErrorv1{}).
 		Post(fmt.Sprintf("%s/%s/acknowledge", basePath, id))
@@ -61,10 +82,7 @@ func (sss *Siteshieldv1) AcknowledgeMap(id string) (*SiteShieldMap, error) {
 	}
 
 	if resp.IsError() {
-		e := resp.Error().(*SiteshieldErrorv1)
-		if e.Status != 0 {
-			return nil, e
-		}
+		return nil, client.NewError(resp)
 	}
 
 	return resp.Result().(*SiteShieldMap), nil
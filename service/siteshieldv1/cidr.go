@@ -0,0 +1,108 @@
+package siteshieldv1
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AcknowledgeRequiredByTime returns deadline of map acknowledgement, zero time when not set.
+func (m *SiteShieldMap) AcknowledgeRequiredByTime() time.Time {
+	return millisToTime(m.AcknowledgeRequiredBy)
+}
+
+// AcknowledgedOnTime returns time when map was acknowledged, zero time when not set.
+func (m *SiteShieldMap) AcknowledgedOnTime() time.Time {
+	return millisToTime(m.AcknowledgedOn)
+}
+
+// PendingAcknowledgement reports whether proposed CIDRs wait for acknowledgement.
+func (m *SiteShieldMap) PendingAcknowledgement() bool {
+	return !m.Acknowledged && len(m.ProposedCidrs) > 0
+}
+
+// CidrChanges returns CIDRs added and removed by proposed map, both sorted.
+func (m *SiteShieldMap) CidrChanges() (added, removed []string) {
+	return DiffCIDRs(m.CurrentCidrs, m.ProposedCidrs)
+}
+
+// DiffCIDRs returns CIDRs of proposed missing in current and CIDRs of current
+// missing in proposed. Both are sorted by address.
+func DiffCIDRs(current, proposed []string) (added, removed []string) {
+	currentSet := cidrSet(current)
+	proposedSet := cidrSet(proposed)
+
+	for c := range proposedSet {
+		if _, ok := currentSet[c]; !ok {
+			added = append(added, c)
+		}
+	}
+	for c := range currentSet {
+		if _, ok := proposedSet[c]; !ok {
+			removed = append(removed, c)
+		}
+	}
+
+	SortCIDRs(added)
+	SortCIDRs(removed)
+
+	return added, removed
+}
+
+// SortCIDRs sorts CIDRs by address family, address and prefix length.
+// Values which are not CIDRs are sorted as strings after all CIDRs.
+func SortCIDRs(cidrs []string) {
+	sort.SliceStable(cidrs, func(i, j int) bool {
+		return lessCIDR(cidrs[i], cidrs[j])
+	})
+}
+
+// lessCIDR compares two CIDRs
+func lessCIDR(a, b string) bool {
+	ipA, netA, errA := net.ParseCIDR(a)
+	ipB, netB, errB := net.ParseCIDR(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return a < b
+	case errA != nil:
+		return false
+	case errB != nil:
+		return true
+	}
+
+	v4A, v4B := ipA.To4() != nil, ipB.To4() != nil
+	if v4A != v4B {
+		return v4A
+	}
+
+	if c := bytes.Compare(netA.IP.To16(), netB.IP.To16()); c != 0 {
+		return c < 0
+	}
+
+	onesA, _ := netA.Mask.Size()
+	onesB, _ := netB.Mask.Size()
+
+	return onesA < onesB
+}
+
+// cidrSet returns set of trimmed, non empty CIDRs
+func cidrSet(cidrs []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(cidrs))
+	for _, c := range cidrs {
+		if c = strings.TrimSpace(c); c != "" {
+			set[c] = struct{}{}
+		}
+	}
+	return set
+}
+
+// millisToTime converts milliseconds since epoch used by API to time
+func millisToTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
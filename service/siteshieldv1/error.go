@@ -28,3 +28,15 @@ func (b SiteshieldErrorv1) Error() string {
 
 	return msg
 }
+
+// ErrorExport represents Site Shield map which cannot be exported.
+type ErrorExport struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorType    string `json:"error_type"`
+}
+
+// Error returns the string representation of the error.
+// Satisfies the error interface.
+func (e ErrorExport) Error() string {
+	return e.ErrorMessage
+}
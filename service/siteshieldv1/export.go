@@ -0,0 +1,388 @@
+package siteshieldv1
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat is firewall configuration format rendered by ExportMap.
+type ExportFormat string
+
+const (
+	// FormatIPTables renders iptables/ip6tables commands
+	FormatIPTables ExportFormat = "iptables"
+
+	// FormatNFTables renders nftables ruleset ( nft -f )
+	FormatNFTables ExportFormat = "nftables"
+
+	// FormatAWSSecurityGroup renders input of `aws ec2 authorize-security-group-ingress --cli-input-json`
+	FormatAWSSecurityGroup ExportFormat = "aws-sg"
+
+	// FormatCSV renders one CIDR per row
+	FormatCSV ExportFormat = "csv"
+)
+
+// Defaults of ExportOptions
+const (
+	defaultExportName     = "siteshield"
+	defaultExportProtocol = "tcp"
+)
+
+// defaultExportPorts are ports opened for Site Shield CIDRs by default
+var defaultExportPorts = []int{80, 443}
+
+// ExportOptions configures ExportMap.
+type ExportOptions struct {
+	Format ExportFormat
+
+	// Proposed renders proposed CIDRs instead of current ones, current CIDRs
+	// are rendered when map does not wait for acknowledgement
+	Proposed bool
+
+	// Diff renders only rules added and removed by proposed CIDRs, nothing
+	// when map does not wait for acknowledgement
+	Diff bool
+
+	// Name of iptables chain and nftables table, `siteshield` by default
+	Name string
+
+	// Protocol and Ports of allowed traffic, tcp ports 80 and 443 by default
+	Protocol string
+	Ports    []int
+
+	// GroupID is ID of AWS security group
+	GroupID string
+}
+
+// exportRules holds CIDRs to be rendered
+type exportRules struct {
+	m    *SiteShieldMap
+	opts ExportOptions
+
+	// cidrs are allowed CIDRs, added and removed are set in diff mode
+	cidrs   []string
+	added   []string
+	removed []string
+}
+
+// ExportMap renders current or proposed CIDRs of Site Shield map as firewall
+// rules. With Diff only rules changed by proposed CIDRs are rendered. Output is
+// ordered by address family, address and prefix length.
+//
+// iptables and nftables output is complete allowlist: traffic to the ports is
+// accepted from the CIDRs and dropped from anywhere else, other traffic is not
+// affected. iptables commands create or flush the chain and jump to it from INPUT
+// only once, nftables ruleset replaces the table, so both can be applied repeatedly.
+//
+//	err := siteshieldv1.ExportMap(os.Stdout, m, siteshieldv1.ExportOptions{
+//		Format: siteshieldv1.FormatIPTables,
+//		Diff:   true,
+//	})
+func ExportMap(w io.Writer, m *SiteShieldMap, opts ExportOptions) error {
+	if opts.Name == "" {
+		opts.Name = defaultExportName
+	}
+	if opts.Protocol == "" {
+		opts.Protocol = defaultExportProtocol
+	}
+	if len(opts.Ports) == 0 {
+		opts.Ports = defaultExportPorts
+	}
+	opts.Ports = append([]int(nil), opts.Ports...)
+	sort.Ints(opts.Ports)
+
+	r := &exportRules{m: m, opts: opts}
+
+	// Proposed CIDRs of acknowledged map are empty or stale, they
+	// must not turn into rules removing the current allowlist
+	switch {
+	case opts.Diff:
+		if m.PendingAcknowledgement() {
+			r.added, r.removed = m.CidrChanges()
+		}
+	case opts.Proposed && m.PendingAcknowledgement():
+		r.cidrs = sortedCIDRs(m.ProposedCidrs)
+	default:
+		r.cidrs = sortedCIDRs(m.CurrentCidrs)
+	}
+
+	for _, cidrs := range [][]string{r.cidrs, r.added, r.removed} {
+		for _, c := range cidrs {
+			if _, _, err := net.ParseCIDR(c); err != nil {
+				return ErrorExport{
+					ErrorMessage: fmt.Sprintf("Invalid CIDR %q in map %d", c, m.ID),
+					ErrorType:    "ErrorExportCIDR",
+				}
+			}
+		}
+	}
+
+	switch opts.Format {
+	case FormatIPTables:
+		return r.iptables(w)
+	case FormatNFTables:
+		return r.nftables(w)
+	case FormatAWSSecurityGroup:
+		return r.awsSecurityGroup(w)
+	case FormatCSV:
+		return r.csv(w)
+	}
+
+	return ErrorExport{
+		ErrorMessage: fmt.Sprintf("Unknown export format %q", opts.Format),
+		ErrorType:    "ErrorExportFormat",
+	}
+}
+
+// header returns comment describing rendered map
+func (r *exportRules) header() string {
+	what := "current CIDRs"
+	switch {
+	case r.opts.Diff:
+		what = "changes of proposed CIDRs"
+	case r.opts.Proposed:
+		what = "proposed CIDRs"
+	}
+
+	return fmt.Sprintf("# Site Shield map %d %s - %s", r.m.ID, r.m.RuleName, what)
+}
+
+// iptables renders iptables and ip6tables commands
+func (r *exportRules) iptables(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, r.header())
+
+	chain := strings.ToUpper(r.opts.Name)
+	ports := joinPorts(r.opts.Ports, ",")
+
+	match := fmt.Sprintf("-p %s -m multiport --dports %s", r.opts.Protocol, ports)
+
+	rule := func(action, cidr string) {
+		fmt.Fprintf(bw, "%s %s %s -s %s %s -j ACCEPT\n", iptablesCommand(cidr), action, chain, cidr, match)
+	}
+
+	if r.opts.Diff {
+		// Added rules are inserted in front of the final DROP
+		for _, c := range r.added {
+			rule("-I", c)
+		}
+		for _, c := range r.removed {
+			rule("-D", c)
+		}
+		return bw.Flush()
+	}
+
+	v4, v6 := splitFamilies(r.cidrs)
+	for _, family := range []struct {
+		cmd   string
+		cidrs []string
+	}{{"iptables", v4}, {"ip6tables", v6}} {
+		cmd := family.cmd
+		fmt.Fprintf(bw, "%s -N %s 2>/dev/null || %s -F %s\n", cmd, chain, cmd, chain)
+		for _, c := range family.cidrs {
+			rule("-A", c)
+		}
+		fmt.Fprintf(bw, "%s -A %s %s -j DROP\n", cmd, chain, match)
+		fmt.Fprintf(bw, "%s -C INPUT %s -j %s 2>/dev/null || %s -I INPUT %s -j %s\n", cmd, match, chain, cmd, match, chain)
+	}
+
+	return bw.Flush()
+}
+
+// nftables renders nftables ruleset with set of CIDRs per address family
+func (r *exportRules) nftables(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, r.header())
+
+	table := strings.ToLower(r.opts.Name)
+	ports := joinPorts(r.opts.Ports, ", ")
+
+	if r.opts.Diff {
+		for _, change := range []struct {
+			action string
+			cidrs  []string
+		}{{"add", r.added}, {"delete", r.removed}} {
+			v4, v6 := splitFamilies(change.cidrs)
+			if len(v4) > 0 {
+				fmt.Fprintf(bw, "%s element inet %s %s_v4 { %s }\n", change.action, table, table, strings.Join(v4, ", "))
+			}
+			if len(v6) > 0 {
+				fmt.Fprintf(bw, "%s element inet %s %s_v6 { %s }\n", change.action, table, table, strings.Join(v6, ", "))
+			}
+		}
+		return bw.Flush()
+	}
+
+	v4, v6 := splitFamilies(r.cidrs)
+
+	// Declaring table before deleting it makes the ruleset apply also when table does not exist
+	fmt.Fprintf(bw, "table inet %s\n", table)
+	fmt.Fprintf(bw, "delete table inet %s\n\n", table)
+	fmt.Fprintf(bw, "table inet %s {\n", table)
+	for _, set := range []struct {
+		family, addrType string
+		cidrs            []string
+	}{{"v4", "ipv4_addr", v4}, {"v6", "ipv6_addr", v6}} {
+		fmt.Fprintf(bw, "\tset %s_%s {\n", table, set.family)
+		fmt.Fprintf(bw, "\t\ttype %s\n", set.addrType)
+		fmt.Fprintf(bw, "\t\tflags interval\n")
+		if len(set.cidrs) > 0 {
+			fmt.Fprintf(bw, "\t\telements = { %s }\n", strings.Join(set.cidrs, ", "))
+		}
+		fmt.Fprintf(bw, "\t}\n\n")
+	}
+
+	fmt.Fprintf(bw, "\tchain input {\n")
+	fmt.Fprintf(bw, "\t\ttype filter hook input priority 0; policy accept;\n")
+	fmt.Fprintf(bw, "\t\tip saddr @%s_v4 %s dport { %s } accept\n", table, r.opts.Protocol, ports)
+	fmt.Fprintf(bw, "\t\tip6 saddr @%s_v6 %s dport { %s } accept\n", table, r.opts.Protocol, ports)
+	fmt.Fprintf(bw, "\t\t%s dport { %s } drop\n", r.opts.Protocol, ports)
+	fmt.Fprintf(bw, "\t}\n")
+	fmt.Fprintf(bw, "}\n")
+
+	return bw.Flush()
+}
+
+// awsIngress is input of AWS security group ingress commands
+type awsIngress struct {
+	GroupID       string          `json:"GroupId,omitempty"`
+	IPPermissions []awsPermission `json:"IpPermissions"`
+}
+
+type awsPermission struct {
+	IPProtocol string         `json:"IpProtocol"`
+	FromPort   int            `json:"FromPort"`
+	ToPort     int            `json:"ToPort"`
+	IPRanges   []awsIPRange   `json:"IpRanges,omitempty"`
+	IPv6Ranges []awsIPv6Range `json:"Ipv6Ranges,omitempty"`
+}
+
+type awsIPRange struct {
+	CidrIP      string `json:"CidrIp"`
+	Description string `json:"Description,omitempty"`
+}
+
+type awsIPv6Range struct {
+	CidrIPv6    string `json:"CidrIpv6"`
+	Description string `json:"Description,omitempty"`
+}
+
+// awsSecurityGroup renders ingress permissions, in diff mode as
+// `Authorize` and `Revoke` inputs
+func (r *exportRules) awsSecurityGroup(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if r.opts.Diff {
+		return enc.Encode(struct {
+			Authorize awsIngress `json:"Authorize"`
+			Revoke    awsIngress `json:"Revoke"`
+		}{r.awsIngress(r.added), r.awsIngress(r.removed)})
+	}
+
+	return enc.Encode(r.awsIngress(r.cidrs))
+}
+
+// awsIngress returns permission per port for given CIDRs
+func (r *exportRules) awsIngress(cidrs []string) awsIngress {
+	ingress := awsIngress{GroupID: r.opts.GroupID, IPPermissions: []awsPermission{}}
+	if len(cidrs) == 0 {
+		return ingress
+	}
+
+	description := fmt.Sprintf("Site Shield %s", r.m.RuleName)
+	v4, v6 := splitFamilies(cidrs)
+
+	for _, port := range r.opts.Ports {
+		p := awsPermission{IPProtocol: r.opts.Protocol, FromPort: port, ToPort: port}
+		for _, c := range v4 {
+			p.IPRanges = append(p.IPRanges, awsIPRange{CidrIP: c, Description: description})
+		}
+		for _, c := range v6 {
+			p.IPv6Ranges = append(p.IPv6Ranges, awsIPv6Range{CidrIPv6: c, Description: description})
+		}
+		ingress.IPPermissions = append(ingress.IPPermissions, p)
+	}
+
+	return ingress
+}
+
+// csv renders CIDR per row, in diff mode with change column
+func (r *exportRules) csv(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	id := strconv.Itoa(r.m.ID)
+
+	if r.opts.Diff {
+		cw.Write([]string{"change", "cidr", "family", "map_id", "rule_name"})
+		for _, c := range r.added {
+			cw.Write([]string{"add", c, cidrFamily(c), id, r.m.RuleName})
+		}
+		for _, c := range r.removed {
+			cw.Write([]string{"remove", c, cidrFamily(c), id, r.m.RuleName})
+		}
+	} else {
+		cw.Write([]string{"cidr", "family", "map_id", "rule_name"})
+		for _, c := range r.cidrs {
+			cw.Write([]string{c, cidrFamily(c), id, r.m.RuleName})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// sortedCIDRs returns sorted copy of CIDRs without duplicates
+func sortedCIDRs(cidrs []string) []string {
+	sorted := make([]string, 0, len(cidrs))
+	for c := range cidrSet(cidrs) {
+		sorted = append(sorted, c)
+	}
+	SortCIDRs(sorted)
+
+	return sorted
+}
+
+// splitFamilies splits sorted CIDRs to IPv4 and IPv6 ones
+func splitFamilies(cidrs []string) (v4, v6 []string) {
+	for _, c := range cidrs {
+		if cidrFamily(c) == "ipv6" {
+			v6 = append(v6, c)
+		} else {
+			v4 = append(v4, c)
+		}
+	}
+	return v4, v6
+}
+
+// cidrFamily returns address family of CIDR
+func cidrFamily(cidr string) string {
+	if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// iptablesCommand returns command handling address family of CIDR
+func iptablesCommand(cidr string) string {
+	if cidrFamily(cidr) == "ipv6" {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+// joinPorts joins ports with separator
+func joinPorts(ports []int, sep string) string {
+	s := make([]string, len(ports))
+	for i, p := range ports {
+		s[i] = strconv.Itoa(p)
+	}
+	return strings.Join(s, sep)
+}
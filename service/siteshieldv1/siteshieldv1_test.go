@@ -0,0 +1,233 @@
+package siteshieldv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupEdgeClient prepares and inits client for making all calls towards Akamai's APIs
+func setupEdgeClient(baseURL string) *Siteshieldv1 {
+	creds, err := edgegrid.NewCredentials().FromJSON(`{ "client_secret": "kljwekfjf", "host": "akab-k2112.31k23jl1k23.luna.akamaiapis.net", "access_token": "akab-l12h3iu123y923huk-4uc54n5xmwhqu4zh", "client_token": "akab-90821u3hkjbnmk-jkhg" }`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	cfg := edgegrid.NewConfig().
+		WithCredentials(creds).
+		WithLogVerbosity("info").
+		WithLocalTesting(true).
+		WithScheme("http").
+		WithTestingURL(baseURL)
+
+	client, err := New(cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return client
+}
+
+// fakeMapServer serves maps and records acknowledgements
+type fakeMapServer struct {
+	mu           sync.Mutex
+	maps         []SiteShieldMap
+	acknowledged []string
+	failures     int
+}
+
+func (f *fakeMapServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":400,"title":"Bad Request"}`)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/siteshield/v1/maps")
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		json.NewEncoder(w).Encode(SiteShieldMaps{SiteShieldMaps: f.maps})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/acknowledge"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/acknowledge")
+		f.acknowledged = append(f.acknowledged, id)
+		for i := range f.maps {
+			if fmt.Sprint(f.maps[i].ID) == id {
+				f.maps[i].Acknowledged = true
+				f.maps[i].CurrentCidrs = f.maps[i].ProposedCidrs
+				json.NewEncoder(w).Encode(f.maps[i])
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestDiffCIDRs(t *testing.T) {
+	added, removed := DiffCIDRs(
+		[]string{"10.0.0.0/24", "192.0.2.0/24", "2001:db8::/32", "9.0.0.0/8"},
+		[]string{" 10.0.0.0/24", "2001:db8:1::/48", "10.0.0.0/16", "100.0.0.0/8", "9.0.0.0/8"},
+	)
+
+	assert.Equal(t, []string{"10.0.0.0/16", "100.0.0.0/8", "2001:db8:1::/48"}, added)
+	assert.Equal(t, []string{"192.0.2.0/24", "2001:db8::/32"}, removed)
+
+	m := SiteShieldMap{AcknowledgeRequiredBy: 1614592800000}
+	assert.True(t, m.AcknowledgeRequiredByTime().Equal(time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, m.AcknowledgedOnTime().IsZero())
+}
+
+func TestWatchMaps(t *testing.T) {
+	fake := &fakeMapServer{
+		failures: 1,
+		maps: []SiteShieldMap{
+			{ID: 1, RuleName: "a;s1.akamai.net", Acknowledged: true, CurrentCidrs: []string{"10.0.0.0/24"}},
+			{ID: 2, RuleName: "b;s2.akamai.net", AcknowledgeRequiredBy: 1614592800000, CurrentCidrs: []string{"10.0.0.0/24"}, ProposedCidrs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+			{ID: 3, RuleName: "c;s3.akamai.net", CurrentCidrs: []string{"10.0.2.0/24"}, ProposedCidrs: []string{"10.0.3.0/24"}},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	apiClient := setupEdgeClient(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var changes []MapChange
+	var errs []error
+	var approvals int
+
+	err := apiClient.WatchMaps(ctx, WatchOptions{
+		Interval: 20 * time.Millisecond,
+		OnChange: func(c MapChange) {
+			changes = append(changes, c)
+		},
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+		Approve: func(ctx context.Context, c MapChange) (bool, error) {
+			approvals++
+			return len(c.Removed) == 0, nil
+		},
+	})
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Len(t, errs, 1, "Failed poll should be reported")
+	assert.Equal(t, 2, approvals, "Every proposal should be approved once")
+	assert.Equal(t, []string{"2"}, fake.acknowledged)
+
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, 2, changes[0].Map.ID)
+		assert.True(t, changes[0].Acknowledged)
+		assert.Equal(t, []string{"10.0.1.0/24"}, changes[0].Added)
+		assert.Empty(t, changes[0].Removed)
+		assert.True(t, changes[0].AcknowledgeRequiredBy.Equal(time.Unix(1614592800, 0)))
+
+		assert.Equal(t, 3, changes[1].Map.ID)
+		assert.False(t, changes[1].Acknowledged)
+		assert.Equal(t, []string{"10.0.2.0/24"}, changes[1].Removed)
+	}
+}
+
+func TestExportMap(t *testing.T) {
+	m := &SiteShieldMap{
+		ID:            7,
+		RuleName:      "www;s7.akamai.net",
+		CurrentCidrs:  []string{"2001:db8::/32", "10.0.1.0/24", "10.0.0.0/24"},
+		ProposedCidrs: []string{"10.0.0.0/24", "192.0.2.0/24", "2001:db8:1::/48"},
+	}
+
+	var b strings.Builder
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatIPTables, Ports: []int{443}}))
+	assert.Equal(t, `# Site Shield map 7 www;s7.akamai.net - current CIDRs
+iptables -N SITESHIELD 2>/dev/null || iptables -F SITESHIELD
+iptables -A SITESHIELD -s 10.0.0.0/24 -p tcp -m multiport --dports 443 -j ACCEPT
+iptables -A SITESHIELD -s 10.0.1.0/24 -p tcp -m multiport --dports 443 -j ACCEPT
+iptables -A SITESHIELD -p tcp -m multiport --dports 443 -j DROP
+iptables -C INPUT -p tcp -m multiport --dports 443 -j SITESHIELD 2>/dev/null || iptables -I INPUT -p tcp -m multiport --dports 443 -j SITESHIELD
+ip6tables -N SITESHIELD 2>/dev/null || ip6tables -F SITESHIELD
+ip6tables -A SITESHIELD -s 2001:db8::/32 -p tcp -m multiport --dports 443 -j ACCEPT
+ip6tables -A SITESHIELD -p tcp -m multiport --dports 443 -j DROP
+ip6tables -C INPUT -p tcp -m multiport --dports 443 -j SITESHIELD 2>/dev/null || ip6tables -I INPUT -p tcp -m multiport --dports 443 -j SITESHIELD
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatIPTables, Diff: true}))
+	assert.Equal(t, `# Site Shield map 7 www;s7.akamai.net - changes of proposed CIDRs
+iptables -I SITESHIELD -s 192.0.2.0/24 -p tcp -m multiport --dports 80,443 -j ACCEPT
+ip6tables -I SITESHIELD -s 2001:db8:1::/48 -p tcp -m multiport --dports 80,443 -j ACCEPT
+iptables -D SITESHIELD -s 10.0.1.0/24 -p tcp -m multiport --dports 80,443 -j ACCEPT
+ip6tables -D SITESHIELD -s 2001:db8::/32 -p tcp -m multiport --dports 80,443 -j ACCEPT
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatNFTables, Proposed: true}))
+	assert.Contains(t, b.String(), "elements = { 10.0.0.0/24, 192.0.2.0/24 }")
+	assert.Contains(t, b.String(), "elements = { 2001:db8:1::/48 }")
+	assert.Contains(t, b.String(), "table inet siteshield\ndelete table inet siteshield\n")
+	assert.Contains(t, b.String(), "ip saddr @siteshield_v4 tcp dport { 80, 443 } accept\n"+
+		"\t\tip6 saddr @siteshield_v6 tcp dport { 80, 443 } accept\n"+
+		"\t\ttcp dport { 80, 443 } drop\n")
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatNFTables, Diff: true, Name: "edge"}))
+	assert.Contains(t, b.String(), "add element inet edge edge_v4 { 192.0.2.0/24 }\n")
+	assert.Contains(t, b.String(), "delete element inet edge edge_v6 { 2001:db8::/32 }\n")
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatAWSSecurityGroup, Diff: true, GroupID: "sg-1", Ports: []int{443}}))
+	var diff struct {
+		Authorize awsIngress
+		Revoke    awsIngress
+	}
+	if assert.NoError(t, json.Unmarshal([]byte(b.String()), &diff)) {
+		assert.Equal(t, "sg-1", diff.Authorize.GroupID)
+		assert.Equal(t, []awsIPRange{{CidrIP: "192.0.2.0/24", Description: "Site Shield www;s7.akamai.net"}}, diff.Authorize.IPPermissions[0].IPRanges)
+		assert.Equal(t, "2001:db8::/32", diff.Revoke.IPPermissions[0].IPv6Ranges[0].CidrIPv6)
+	}
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, m, ExportOptions{Format: FormatCSV}))
+	assert.Equal(t, "cidr,family,map_id,rule_name\n10.0.0.0/24,ipv4,7,www;s7.akamai.net\n10.0.1.0/24,ipv4,7,www;s7.akamai.net\n2001:db8::/32,ipv6,7,www;s7.akamai.net\n", b.String())
+
+	// Acknowledged map has nothing to change
+	acknowledged := &SiteShieldMap{ID: 8, RuleName: "api;s8.akamai.net", Acknowledged: true, CurrentCidrs: []string{"10.0.0.0/24", "2001:db8::/32"}}
+	for _, format := range []ExportFormat{FormatIPTables, FormatNFTables, FormatCSV} {
+		b.Reset()
+		assert.NoError(t, ExportMap(&b, acknowledged, ExportOptions{Format: format, Diff: true}))
+		assert.NotContains(t, b.String(), "10.0.0.0/24", "Diff of acknowledged map should be empty ( %s )", format)
+	}
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, acknowledged, ExportOptions{Format: FormatAWSSecurityGroup, Diff: true}))
+	assert.NotContains(t, b.String(), "10.0.0.0/24")
+
+	b.Reset()
+	assert.NoError(t, ExportMap(&b, acknowledged, ExportOptions{Format: FormatCSV, Proposed: true}))
+	assert.Contains(t, b.String(), "10.0.0.0/24", "Current CIDRs should be rendered when nothing is proposed")
+
+	var exportErr ErrorExport
+	err := ExportMap(&b, m, ExportOptions{Format: "pf"})
+	if assert.True(t, errors.As(err, &exportErr)) {
+		assert.Equal(t, "ErrorExportFormat", exportErr.ErrorType)
+	}
+
+	err = ExportMap(&b, &SiteShieldMap{CurrentCidrs: []string{"10.0.0.1"}}, ExportOptions{Format: FormatCSV})
+	assert.True(t, errors.As(err, &exportErr))
+}
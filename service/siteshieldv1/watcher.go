@@ -0,0 +1,176 @@
+package siteshieldv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apiheat/go-edgegrid/v6/edgegrid/client"
+)
+
+// defaultWatchInterval is time between ListMaps calls
+const defaultWatchInterval = 10 * time.Minute
+
+// MapChange describes Site Shield map which requires acknowledgement.
+type MapChange struct {
+	Map *SiteShieldMap
+
+	// Added and Removed are CIDRs changed by the proposed map
+	Added   []string
+	Removed []string
+
+	// AcknowledgeRequiredBy is deadline of acknowledgement
+	AcknowledgeRequiredBy time.Time
+
+	// Acknowledged is set when map was acknowledged by the watcher
+	Acknowledged bool
+
+	// Err is error of approval or acknowledgement
+	Err error
+}
+
+// MapApprover decides whether map change can be acknowledged automatically.
+type MapApprover func(ctx context.Context, change MapChange) (bool, error)
+
+// WatchOptions configures WatchMaps.
+type WatchOptions struct {
+	// Interval is time between polls, 10 minutes by default
+	Interval time.Duration
+
+	// OnChange is called once for every proposed map which requires acknowledgement
+	OnChange func(MapChange)
+
+	// Approve enables automatic acknowledgement of approved changes
+	Approve MapApprover
+
+	// OnError is called when maps cannot be listed, watcher keeps polling
+	OnError func(error)
+}
+
+// PendingMapChanges returns changes of all maps which wait for acknowledgement.
+func (sss *Siteshieldv1) PendingMapChanges(ctx context.Context) ([]MapChange, error) {
+	maps, err := sss.ListMapsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []MapChange
+	for i := range maps.SiteShieldMaps {
+		m := &maps.SiteShieldMaps[i]
+		if !m.PendingAcknowledgement() {
+			continue
+		}
+
+		change := MapChange{
+			Map:                   m,
+			AcknowledgeRequiredBy: m.AcknowledgeRequiredByTime(),
+		}
+		change.Added, change.Removed = m.CidrChanges()
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// WatchMaps polls Site Shield maps until context is done and reports every
+// proposed map once. When approver is set, approved changes are acknowledged.
+//
+//	err := svc.WatchMaps(ctx, siteshieldv1.WatchOptions{
+//		OnChange: func(c siteshieldv1.MapChange) {
+//			fmt.Println(c.Map.RuleName, c.Added, c.Removed, c.AcknowledgeRequiredBy)
+//		},
+//		Approve: func(ctx context.Context, c siteshieldv1.MapChange) (bool, error) {
+//			return firewall.Apply(ctx, c.Added) == nil, nil
+//		},
+//	})
+func (sss *Siteshieldv1) WatchMaps(ctx context.Context, opts WatchOptions) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	// Proposals already reported, keyed by map and proposed CIDRs
+	seen := map[string]struct{}{}
+
+	for {
+		changes, err := sss.PendingMapChanges(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			sss.Client.Logger.Debugf("Listing Site Shield maps failed: %s", err)
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+		}
+
+		pending := map[string]struct{}{}
+		for _, change := range changes {
+			key := proposalKey(change.Map)
+			pending[key] = struct{}{}
+
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			change = sss.approve(ctx, opts.Approve, change)
+
+			// Failed acknowledgement is retried with the next poll
+			if change.Err == nil {
+				seen[key] = struct{}{}
+			}
+
+			if opts.OnChange != nil {
+				opts.OnChange(change)
+			}
+		}
+
+		// Forget proposals which are not pending anymore
+		if err == nil {
+			for key := range seen {
+				if _, ok := pending[key]; !ok {
+					delete(seen, key)
+				}
+			}
+		}
+
+		if err := client.SleepWithContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// approve asks approver and acknowledges approved change
+func (sss *Siteshieldv1) approve(ctx context.Context, approver MapApprover, change MapChange) MapChange {
+	if approver == nil {
+		return change
+	}
+
+	ok, err := approver(ctx, change)
+	if err != nil || !ok {
+		change.Err = err
+		return change
+	}
+
+	m, err := sss.AcknowledgeMapWithContext(ctx, fmt.Sprint(change.Map.ID))
+	if err != nil {
+		change.Err = err
+		return change
+	}
+
+	change.Map = m
+	change.Acknowledged = true
+
+	return change
+}
+
+// proposalKey identifies proposed CIDRs of a map
+func proposalKey(m *SiteShieldMap) string {
+	proposed := append([]string(nil), m.ProposedCidrs...)
+	SortCIDRs(proposed)
+
+	return fmt.Sprintf("%d/%s", m.ID, strings.Join(proposed, ","))
+}